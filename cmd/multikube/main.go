@@ -14,6 +14,7 @@ import (
 	"log/slog"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -25,6 +26,7 @@ import (
 	"buf.build/go/protovalidate"
 	"github.com/SermoDigital/jose/crypto"
 	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/cmdutil"
 	"github.com/amimof/multikube/pkg/compile"
 	"github.com/amimof/multikube/pkg/controller"
 	"github.com/amimof/multikube/pkg/events"
@@ -76,7 +78,15 @@ var (
 
 	oidcPollInterval       time.Duration
 	oidcIssuerURL          string
+	oidcClientID           string
 	oidcUsernameClaim      string
+	oidcUsernamePrefix     string
+	oidcUsernameExpression string
+	oidcGroupsClaim        string
+	oidcGroupsPrefix       string
+	oidcGroupsExpression   string
+	oidcExtraClaims        []string
+	oidcExtraExpressions   []string
 	oidcCaFile             string
 	oidcInsecureSkipVerify bool
 	tlsListenLimit         int
@@ -88,6 +98,8 @@ var (
 	tlsCACertificate       string
 
 	rs256PublicKey string
+	execCommands   []string
+	tokenFileDirs  []string
 	kubeconfigPath string
 	cacheTTL       time.Duration
 	dataPath       string
@@ -128,9 +140,19 @@ func init() {
 	pflag.StringVar(&tlsCertificateKey, "tls-key", "", "the private key to use for secure conections")
 	pflag.StringVar(&tlsCACertificate, "tls-ca", "", "the certificate authority file to be used with mutual tls auth")
 	pflag.StringVar(&rs256PublicKey, "rs256-public-key", "", "the RS256 public key used to validate the signature of client JWT's")
+	pflag.StringArrayVar(&execCommands, "allow-exec-command", nil, "Command that exec credentials may run to authenticate to backends (can be specified multiple times). Exec credentials are rejected unless their command is allowed")
+	pflag.StringArrayVar(&tokenFileDirs, "allow-token-file-dir", nil, "Directory that token file credentials may read from, including its subdirectories (can be specified multiple times). Token file credentials are rejected unless their file is in an allowed directory")
 	pflag.StringVar(&kubeconfigPath, "kubeconfig", "/etc/multikube/kubeconfig", "absolute path to a kubeconfig file")
 	pflag.StringVar(&oidcIssuerURL, "oidc-issuer-url", "", "The URL of the OpenID issuer, only HTTPS scheme will be accepted. If set, it will be used to verify the OIDC JSON Web Token (JWT)")
+	pflag.StringVar(&oidcClientID, "oidc-client-id", "", "The client ID tokens must be issued for, in their aud claim. Required with --oidc-issuer-url")
 	pflag.StringVar(&oidcUsernameClaim, "oidc-username-claim", "sub", " The OpenID claim to use as the user name. Note that claims other than the default is not guaranteed to be unique and immutable")
+	pflag.StringVar(&oidcUsernamePrefix, "oidc-username-prefix", "", "Prefix prepended to usernames read from --oidc-username-claim (e.g. oidc:)")
+	pflag.StringVar(&oidcUsernameExpression, "oidc-username-expression", "", "CEL expression over the token claims evaluating to the username (e.g. claims.email). Takes precedence over --oidc-username-claim")
+	pflag.StringVar(&oidcGroupsClaim, "oidc-groups-claim", "groups", "The claim holding the groups of the user, either an array or a comma separated string")
+	pflag.StringVar(&oidcGroupsPrefix, "oidc-groups-prefix", "", "Prefix prepended to groups read from --oidc-groups-claim (e.g. oidc:)")
+	pflag.StringVar(&oidcGroupsExpression, "oidc-groups-expression", "", "CEL expression over the token claims evaluating to the groups of the user. Takes precedence over --oidc-groups-claim")
+	pflag.StringArrayVar(&oidcExtraClaims, "oidc-extra-claim", nil, "Extra user attribute read from a claim in key=claim format, passed on to impersonating backends (can be specified multiple times)")
+	pflag.StringArrayVar(&oidcExtraExpressions, "oidc-extra-expression", nil, "Extra user attribute derived from a CEL expression over the token claims in key=expression format (can be specified multiple times)")
 	pflag.StringVar(&oidcCaFile, "oidc-ca-file", "", "the certificate authority file to be used for verifyign the OpenID server")
 	pflag.StringVar(&dataPath, "data-path", defaultStatePath, "Directory to store state")
 	pflag.StringVar(&logLevel, "log-level", "info", "The level of verbosity of log output")
@@ -243,6 +265,16 @@ func main() {
 		Exchange: exchange,
 		Logger:   log,
 	})
+	credentialService := transport.NewCredentialService(&app.CredentialService{
+		Repo:     repository.NewCredentialRepo(repo),
+		Exchange: exchange,
+		Logger:   log,
+	})
+	policyService := transport.NewPolicyService(&app.PolicyService{
+		Repo:     repository.NewPolicyRepo(repo),
+		Exchange: exchange,
+		Logger:   log,
+	})
 	routeService := transport.NewRouteService(&app.RouteService{
 		Repo:     repository.NewRouteRepo(repo),
 		Exchange: exchange,
@@ -317,6 +349,8 @@ func main() {
 		backendService,
 		caService,
 		certService,
+		credentialService,
+		policyService,
 		routeService,
 	)
 
@@ -387,7 +421,10 @@ func main() {
 
 	// Setup controller
 	runtimeStore := proxyv2.NewRuntimeStore()
-	compiler := compile.NewCompiler()
+	compiler := compile.NewCompiler(
+		compile.WithExecCommands(execCommands...),
+		compile.WithTokenFileDirs(tokenFileDirs...),
+	)
 	ctrl := controller.New(
 		cs,
 		controller.WithLogger(log),
@@ -399,7 +436,59 @@ func main() {
 	go ctrl.Run(ctx)
 	log.Info("started proxy Controller")
 
-	handler := proxyv2.NewProxy(runtimeStore)
+	// Setup active health checking of backend targets
+	healthChecker := proxyv2.NewHealthChecker(
+		runtimeStore,
+		proxyv2.WithHealthLogger(log),
+		proxyv2.WithHealthStatusFunc(controller.BackendStatusWriter(cs, log)),
+	)
+	go healthChecker.Run(ctx)
+
+	// Setup authenticators for the proxy. Claim mappings apply to tokens
+	// verified by either authenticator.
+	claimMapper, err := proxyv2.NewClaimMapper(proxyv2.ClaimMapping{
+		UsernameClaim:      oidcUsernameClaim,
+		UsernamePrefix:     oidcUsernamePrefix,
+		UsernameExpression: oidcUsernameExpression,
+		GroupsClaim:        oidcGroupsClaim,
+		GroupsPrefix:       oidcGroupsPrefix,
+		GroupsExpression:   oidcGroupsExpression,
+		ExtraClaims:        cmdutil.ConvertKVStringsToMap(oidcExtraClaims),
+		ExtraExpressions:   cmdutil.ConvertKVStringsToMap(oidcExtraExpressions),
+	})
+	if err != nil {
+		log.Error("error setting up claim mappings", "error", err)
+		os.Exit(1)
+	}
+
+	var authenticators []proxyv2.Authenticator
+	if oidcIssuerURL != "" {
+		if oidcClientID == "" {
+			log.Error("--oidc-client-id is required with --oidc-issuer-url")
+			os.Exit(1)
+		}
+		oidcAuth := proxyv2.NewOIDCAuthenticator(proxyv2.OIDCConfig{
+			IssuerURL:          oidcIssuerURL,
+			ClientID:           oidcClientID,
+			Claims:             claimMapper,
+			PollInterval:       oidcPollInterval,
+			InsecureSkipVerify: oidcInsecureSkipVerify,
+			CA:                 readCert(oidcCaFile),
+			Logger:             log,
+		})
+		go oidcAuth.Run(ctx)
+		authenticators = append(authenticators, oidcAuth)
+	}
+	if rs256PublicKey != "" {
+		authenticators = append(authenticators, proxyv2.NewRS256Authenticator(readPublicKey(rs256PublicKey), claimMapper))
+	}
+
+	var handler http.Handler = proxyv2.NewProxy(runtimeStore, proxyv2.WithAuditLogger(log))
+	if len(authenticators) > 0 {
+		handler = proxyv2.WithAuthentication(proxyv2.NewUnionAuthenticator(authenticators...))(handler)
+	} else {
+		log.Warn("no authenticator configured, proxy will accept unauthenticated requests")
+	}
 
 	// Create the server
 	s := &server.Server{
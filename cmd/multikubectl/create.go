@@ -29,6 +29,8 @@ multikube create routes
 	cmd.AddCommand(newCreateBackendCmd(cfg))
 	cmd.AddCommand(newCreateRouteCmd(cfg))
 	cmd.AddCommand(newCreateCertificateCmd(cfg))
+	cmd.AddCommand(newCreateCredentialCmd(cfg))
+	cmd.AddCommand(newCreatePolicyCmd(cfg))
 	cmd.AddCommand(newCreateCACmd(cfg))
 
 	return cmd
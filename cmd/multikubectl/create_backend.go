@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/amimof/multikube/pkg/client"
@@ -18,12 +20,19 @@ import (
 
 func newCreateBackendCmd(cfg *client.Config) *cobra.Command {
 	var (
-		server          string
-		caRef           string
-		authRef         string
-		insecureSkipTLS bool
-		cacheTTL        time.Duration
-		labels          []string
+		server              string
+		targets             []string
+		caRef               string
+		authRef             string
+		insecureSkipTLS     bool
+		cacheTTL            time.Duration
+		cacheMaxBytes       uint64
+		impersonate         bool
+		maxInFlight         uint32
+		maxLongRunning      uint32
+		decorateLabels      []string
+		decorateAnnotations []string
+		labels              []string
 	)
 
 	cmd := &cobra.Command{
@@ -32,20 +41,25 @@ func newCreateBackendCmd(cfg *client.Config) *cobra.Command {
 		Long:  `Create a new backend and register it with the server.`,
 		Args:  cobra.ExactArgs(1),
 		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
-			return runCreateBackendCmd(cmd, args, cfg, server, caRef, authRef, insecureSkipTLS, cacheTTL, labels)
+			return runCreateBackendCmd(cmd, args, cfg, server, targets, caRef, authRef, insecureSkipTLS, cacheTTL, cacheMaxBytes, impersonate, maxInFlight, maxLongRunning, decorateLabels, decorateAnnotations, labels)
 		}),
 	}
 
-	cmd.Flags().StringVar(&server, "server", "", "Address of the Kubernetes API server for this backend (required)")
+	cmd.Flags().StringVar(&server, "server", "", "Address of the Kubernetes API server for this backend")
+	cmd.Flags().StringArrayVar(&targets, "target", nil, "Additional API server address in URL[,WEIGHT] format (can be specified multiple times)")
 	cmd.Flags().StringVar(&caRef, "ca-ref", "", "Reference to the CA certificate secret")
 	cmd.Flags().StringVar(&authRef, "auth-ref", "", "Reference to the authentication secret")
 	cmd.Flags().BoolVar(&insecureSkipTLS, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for the backend server")
 	cmd.Flags().DurationVar(&cacheTTL, "cache-ttl", 0, "Cache time-to-live duration (e.g. 30s, 5m, 1h). Zero means no caching.")
+	cmd.Flags().Uint64Var(&cacheMaxBytes, "cache-max-bytes", 0, "Maximum total size of cached responses in bytes. Zero means the default of 64MiB.")
+	cmd.Flags().BoolVar(&impersonate, "impersonate", false, "Access the backend with its own credentials and impersonate the authenticated identity of clients")
+	cmd.Flags().Uint32Var(&maxInFlight, "max-in-flight", 0, "Maximum number of short requests in flight to the backend. Zero means unlimited.")
+	cmd.Flags().Uint32Var(&maxLongRunning, "max-long-running", 0, "Maximum number of watches and other long running requests in flight to the backend. Requires --max-in-flight.")
+	cmd.Flags().StringArrayVar(&decorateLabels, "decorate-label", nil, "Label to set on returned objects in NAME=BACKEND_LABEL format, taking its value from a label of the backend (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&decorateAnnotations, "decorate-annotation", nil, "Annotation to set on returned objects in NAME=BACKEND_LABEL format, taking its value from a label of the backend (can be specified multiple times)")
 	cmd.Flags().StringArrayVar(&labels, "label", nil, "Labels to attach in key=value format (can be specified multiple times)")
 
-	if err := cmd.MarkFlagRequired("server"); err != nil {
-		logrus.Fatalf("error marking flag as required: %v", err)
-	}
+	cmd.MarkFlagsOneRequired("server", "target")
 
 	return cmd
 }
@@ -55,9 +69,15 @@ func runCreateBackendCmd(
 	cmd *cobra.Command,
 	args []string,
 	cfg *client.Config,
-	server, caRef, authRef string,
+	server string,
+	targetStrs []string,
+	caRef, authRef string,
 	insecureSkipTLS bool,
 	cacheTTL time.Duration,
+	cacheMaxBytes uint64,
+	impersonate bool,
+	maxInFlight, maxLongRunning uint32,
+	decorateLabelStrs, decorateAnnotationStrs []string,
 	labelStrs []string,
 ) error {
 	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
@@ -69,6 +89,15 @@ func runCreateBackendCmd(
 
 	name := args[0]
 
+	if maxLongRunning > 0 && maxInFlight == 0 {
+		return fmt.Errorf("--max-long-running requires --max-in-flight")
+	}
+
+	targets, err := parseBackendTargets(targetStrs)
+	if err != nil {
+		return err
+	}
+
 	// Setup client
 	currentSrv, err := cfg.CurrentServer()
 	if err != nil {
@@ -93,6 +122,7 @@ func runCreateBackendCmd(
 		Config: &backendv1.BackendConfig{
 			Name:                  name,
 			Server:                server,
+			Targets:               targets,
 			CaRef:                 caRef,
 			AuthRef:               authRef,
 			InsecureSkipTlsVerify: insecureSkipTLS,
@@ -101,6 +131,23 @@ func runCreateBackendCmd(
 
 	if cacheTTL > 0 {
 		backend.Config.CacheTtl = durationpb.New(cacheTTL)
+		backend.Config.CacheMaxBytes = cacheMaxBytes
+	}
+	if impersonate {
+		backend.Config.Impersonation = backendv1.ImpersonationMode_IMPERSONATION_MODE_IDENTITY
+	}
+	if maxInFlight > 0 {
+		backend.Config.FlowControl = &backendv1.FlowControl{
+			MaxInFlight:    maxInFlight,
+			MaxLongRunning: maxLongRunning,
+		}
+	}
+
+	if len(decorateLabelStrs) > 0 || len(decorateAnnotationStrs) > 0 {
+		backend.Config.Decoration = &backendv1.Decoration{
+			Labels:      cmdutil.ConvertKVStringsToMap(decorateLabelStrs),
+			Annotations: cmdutil.ConvertKVStringsToMap(decorateAnnotationStrs),
+		}
 	}
 
 	if err := c.BackendV1().Create(ctx, backend); err != nil {
@@ -111,3 +158,21 @@ func runCreateBackendCmd(
 
 	return nil
 }
+
+// parseBackendTargets parses targets in URL[,WEIGHT] format.
+func parseBackendTargets(values []string) ([]*backendv1.BackendTarget, error) {
+	out := make([]*backendv1.BackendTarget, 0, len(values))
+	for _, value := range values {
+		server, weightStr, found := strings.Cut(value, ",")
+		target := &backendv1.BackendTarget{Server: server}
+		if found {
+			weight, err := strconv.ParseUint(weightStr, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight in target %q: %w", value, err)
+			}
+			target.Weight = uint32(weight)
+		}
+		out = append(out, target)
+	}
+	return out, nil
+}
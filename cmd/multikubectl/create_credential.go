@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
+	metav1 "github.com/amimof/multikube/api/meta/v1"
+	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/cmdutil"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newCreateCredentialCmd(cfg *client.Config) *cobra.Command {
+	var (
+		clientCertificate string
+		token             string
+		username          string
+		password          string
+		tokenFile         string
+		exec              credentialv1.ExecConfig
+		execEnv           []string
+		refreshBefore     time.Duration
+		labels            []string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "credential [NAME]",
+		Aliases: []string{"cred"},
+		Short:   "Create a new credential",
+		Long: `Create a new credential and register it with the server. Backends refer to
+credentials by name with --auth-ref and authenticate with them to the API
+servers they forward to.`,
+		Example: `  # Create a credential presenting a client certificate
+  multikubectl create credential my-cred --client-certificate my-cert
+
+  # Create a bearer token credential
+  multikubectl create credential my-cred --token "$(cat token)"
+
+  # Create a basic auth credential
+  multikubectl create credential my-cred --username admin --password secret
+
+  # Create a credential reading a token that is rotated on disk
+  multikubectl create credential my-cred \
+    --token-file /var/run/secrets/tokens/cluster-a
+
+  # Create a credential running a kubectl exec credential plugin
+  multikubectl create credential my-cred \
+    --exec-command aws \
+    --exec-arg eks --exec-arg get-token --exec-arg --cluster-name=prod \
+    --exec-env AWS_PROFILE=prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
+			if exec.GetCommand() != "" {
+				exec.Env = cmdutil.ConvertKVStringsToMap(execEnv)
+				if refreshBefore > 0 {
+					exec.RefreshBefore = durationpb.New(refreshBefore)
+				}
+			}
+			return runCreateCredentialCmd(cmd, args, cfg, clientCertificate, token, username, password, tokenFile, &exec, labels)
+		}),
+	}
+
+	cmd.Flags().StringVar(&clientCertificate, "client-certificate", "", "Name of the certificate to present as client certificate")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token")
+	cmd.Flags().StringVar(&username, "username", "", "Username for basic authentication")
+	cmd.Flags().StringVar(&password, "password", "", "Password for basic authentication")
+	cmd.Flags().StringVar(&tokenFile, "token-file", "", "Path of a file holding a bearer token, read again when it changes. The server must allow its directory with --allow-token-file-dir")
+	cmd.Flags().StringVar(&exec.Command, "exec-command", "", "Command of an exec credential plugin producing bearer tokens, which the server must allow with --allow-exec-command")
+	cmd.Flags().StringArrayVar(&exec.Args, "exec-arg", nil, "Argument to the exec credential plugin (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&execEnv, "exec-env", nil, "Environment variable of the exec credential plugin in key=value format (can be specified multiple times)")
+	cmd.Flags().StringVar(&exec.ApiVersion, "exec-api-version", "", "ExecCredential version of the exec credential plugin (default client.authentication.k8s.io/v1)")
+	cmd.Flags().DurationVar(&refreshBefore, "exec-refresh-before", 0, "How long before expiry tokens of the exec credential plugin are refreshed (default 1m)")
+	cmd.Flags().StringArrayVar(&labels, "label", nil, "Labels to attach in key=value format (can be specified multiple times)")
+
+	cmd.MarkFlagsMutuallyExclusive("client-certificate", "token", "username", "token-file", "exec-command")
+	cmd.MarkFlagsOneRequired("client-certificate", "token", "username", "token-file", "exec-command")
+	cmd.MarkFlagsRequiredTogether("username", "password")
+
+	return cmd
+}
+
+// runCreateCredentialCmd creates a new credential
+func runCreateCredentialCmd(
+	cmd *cobra.Command,
+	args []string,
+	cfg *client.Config,
+	clientCertificate, token, username, password, tokenFile string,
+	exec *credentialv1.ExecConfig,
+	labelStrs []string,
+) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.credential.create")
+	defer span.End()
+
+	name := args[0]
+
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+
+	credConfig := &credentialv1.CredentialConfig{
+		Name:                 name,
+		ClientCertificateRef: clientCertificate,
+		Token:                token,
+		TokenFile:            tokenFile,
+	}
+	if username != "" {
+		credConfig.Basic = &credentialv1.BasicAuth{
+			Username: username,
+			Password: password,
+		}
+	}
+	if exec.GetCommand() != "" {
+		credConfig.Exec = exec
+	}
+
+	cred := &credentialv1.Credential{
+		Meta: &metav1.Meta{
+			Name:   name,
+			Labels: cmdutil.ConvertKVStringsToMap(labelStrs),
+		},
+		Config: credConfig,
+	}
+
+	if err := c.CredentialV1().Create(ctx, cred); err != nil {
+		logrus.Fatalf("error creating credential: %v", err)
+	}
+
+	fmt.Printf("credential %q created\n", name)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "github.com/amimof/multikube/api/meta/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
+	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/cmdutil"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+)
+
+func newCreatePolicyCmd(cfg *client.Config) *cobra.Command {
+	var (
+		expression         string
+		allow              bool
+		deny               bool
+		message            string
+		routes             []string
+		validations        []string
+		defaultLabels      []string
+		defaultAnnotations []string
+		labels             []string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "policy [NAME]",
+		Aliases: []string{"pol"},
+		Short:   "Create a new policy",
+		Long: `Create a new policy and register it with the server. Policies allow or deny
+requests through the proxy that match a CEL expression over the variables
+user, request, backend and now. Deny policies take precedence over allow
+policies, and once an allow policy applies to a route only the requests it
+matches are allowed.
+
+Policies with validations or default labels and annotations check the
+objects of the create, update and patch requests they match. Validations are
+CEL expressions that also have the variable object, and must all hold for a
+request to be forwarded.`,
+		Example: `  # Allow bob to read pods in the dev namespace
+  multikubectl create policy bob-dev-pods --allow \
+    --expression 'user.name == "bob" && request.namespace == "dev" && request.resource == "pods" && request.verb in ["get", "list", "watch"]'
+
+  # Deny deletes in production backends outside office hours
+  multikubectl create policy prod-deletes --deny \
+    --expression 'backend.labels.env == "prod" && request.verb == "delete" && now.getHours("Europe/Stockholm") >= 17' \
+    --message "deletes in production are only allowed during office hours"
+
+  # Deny access to secrets through a single route
+  multikubectl create policy no-secrets --deny --route my-route \
+    --expression 'request.resource == "secrets"'
+
+  # Reject privileged pods and require a team label
+  multikubectl create policy pod-guardrails \
+    --expression 'request.resource == "pods"' \
+    --validation '!has(object.spec.containers) || !object.spec.containers.exists(c, has(c.securityContext) && has(c.securityContext.privileged) && c.securityContext.privileged)' \
+    --validation 'request.verb == "patch" || (has(object.metadata.labels) && has(object.metadata.labels.team))' \
+    --message "pods must be unprivileged and labeled with their team"
+
+  # Set a default owner label on everything created in the dev namespace
+  multikubectl create policy dev-defaults \
+    --expression 'request.namespace == "dev"' \
+    --default-label owner=platform`,
+		Args: cobra.ExactArgs(1),
+		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
+			mutation := &policyv1.Mutation{
+				Labels:      cmdutil.ConvertKVStringsToMap(defaultLabels),
+				Annotations: cmdutil.ConvertKVStringsToMap(defaultAnnotations),
+			}
+			return runCreatePolicyCmd(cmd, args, cfg, expression, allow, deny, message, routes, validations, mutation, labels)
+		}),
+	}
+
+	cmd.Flags().StringVar(&expression, "expression", "", "CEL expression matching the requests of the policy")
+	cmd.Flags().BoolVar(&allow, "allow", false, "Allow the matching requests")
+	cmd.Flags().BoolVar(&deny, "deny", false, "Deny the matching requests")
+	cmd.Flags().StringVar(&message, "message", "", "Message returned to clients denied by the policy")
+	cmd.Flags().StringArrayVar(&routes, "route", nil, "Name of a route the policy applies to (can be specified multiple times, default all routes)")
+	cmd.Flags().StringArrayVar(&validations, "validation", nil, "CEL expression the objects of the matching requests must satisfy (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&defaultLabels, "default-label", nil, "Label set on the objects of the matching requests that do not have it, in key=value format (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&defaultAnnotations, "default-annotation", nil, "Annotation set on the objects of the matching requests that do not have it, in key=value format (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&labels, "label", nil, "Labels to attach in key=value format (can be specified multiple times)")
+
+	_ = cmd.MarkFlagRequired("expression")
+	cmd.MarkFlagsMutuallyExclusive("allow", "deny")
+	cmd.MarkFlagsOneRequired("allow", "deny", "validation", "default-label", "default-annotation")
+
+	return cmd
+}
+
+// runCreatePolicyCmd creates a new policy
+func runCreatePolicyCmd(
+	cmd *cobra.Command,
+	args []string,
+	cfg *client.Config,
+	expression string,
+	allow, deny bool,
+	message string,
+	routes, validations []string,
+	mutation *policyv1.Mutation,
+	labelStrs []string,
+) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.policy.create")
+	defer span.End()
+
+	name := args[0]
+
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+
+	policyConfig := &policyv1.PolicyConfig{
+		Name:       name,
+		Expression: expression,
+		Message:    message,
+		Routes:     routes,
+	}
+	switch {
+	case allow:
+		policyConfig.Effect = policyv1.Effect_EFFECT_ALLOW
+	case deny:
+		policyConfig.Effect = policyv1.Effect_EFFECT_DENY
+	}
+	for _, v := range validations {
+		policyConfig.Validations = append(policyConfig.Validations, &policyv1.Validation{Expression: v})
+	}
+	if len(mutation.GetLabels()) > 0 || len(mutation.GetAnnotations()) > 0 {
+		policyConfig.Mutation = mutation
+	}
+
+	policy := &policyv1.Policy{
+		Meta: &metav1.Meta{
+			Name:   name,
+			Labels: cmdutil.ConvertKVStringsToMap(labelStrs),
+		},
+		Config: policyConfig,
+	}
+
+	if err := c.PolicyV1().Create(ctx, policy); err != nil {
+		logrus.Fatalf("error creating policy: %v", err)
+	}
+
+	fmt.Printf("policy %q created\n", name)
+
+	return nil
+}
@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	metav1 "github.com/amimof/multikube/api/meta/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
@@ -18,13 +21,20 @@ import (
 func newCreateRouteCmd(cfg *client.Config) *cobra.Command {
 	var (
 		backendRef  string
-		path        string
-		pathPrefix  string
-		sni         string
 		headerName  string
 		headerValue string
 		jwtClaim    string
 		jwtValue    string
+		timeout     time.Duration
+		idleTimeout time.Duration
+		maxBodySize uint64
+		rewrite     routev1.Rewrite
+		rewriteRe   routev1.RegexRewrite
+		fanOut      routev1.FanOut
+		priority    int32
+		match       routev1.Match
+		namespace   routev1.NamespaceMatch
+		rateLimits  []string
 		labels      []string
 	)
 
@@ -47,27 +57,81 @@ The NAME argument is required and sets the route's name.`,
   multikubectl route create my-route --backend-ref my-cluster \
     --header-name X-Tenant --header-value acme
 
+  # Create a route matching read requests to paths matching a regular expression
+  multikubectl route create my-route --backend-ref my-cluster \
+    --path-regex '^/api/v1/namespaces/[^/]+/pods' --method GET --method HEAD
+
+  # Send read requests for namespaces starting with team-a- to a read replica
+  multikubectl route create my-route --backend-ref my-replica \
+    --namespace-prefix team-a- --verb get --verb list --verb watch
+
+  # Send requests for a custom API group to another cluster
+  multikubectl route create my-route --backend-ref gpu-cluster --api-group ml.example.com
+
   # Create a route with JWT claim matching
   multikubectl route create my-route --backend-ref my-cluster \
     --jwt-claim tenant --jwt-value acme
 
+  # Create a route that must match both SNI and a path prefix, taking
+  # precedence over routes with a lower priority
+  multikubectl route create my-route --backend-ref my-cluster \
+    --sni api.example.com --path-prefix /apis --priority 10
+
+  # Expose a cluster under a sub-path of the proxy
+  multikubectl route create my-route --backend-ref my-cluster \
+    --path-prefix /clusters/my-cluster --strip-prefix
+
+  # Create a route with a request timeout and a body size limit
+  multikubectl route create my-route --backend-ref my-cluster \
+    --timeout 2m --max-request-body-bytes 3145728
+
+  # Limit every user to 10 requests per second with bursts of 20, and the
+  # route to 100 requests per second in total
+  multikubectl route create my-route --backend-ref my-cluster \
+    --rate-limit user=10:20 --rate-limit route=100
+
+  # List pods of three clusters at once, sending other requests to the first
+  multikubectl route create all-clusters --backend-ref cluster-a \
+    --path-prefix /clusters/all --strip-prefix \
+    --fan-out cluster-a --fan-out cluster-b --fan-out cluster-c
+
   # Create a route with labels
   multikubectl route create my-route --backend-ref my-cluster \
     --label env=production --label team=platform`,
 		Args: cobra.ExactArgs(1),
 		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
-			return runCreateCreateCmd(cmd, args, cfg, backendRef, path, pathPrefix, sni, headerName, headerValue, jwtClaim, jwtValue, labels)
+			return runCreateCreateCmd(cmd, args, cfg, backendRef, &match, &namespace, headerName, headerValue, jwtClaim, jwtValue, timeout, idleTimeout, maxBodySize, &rewrite, &rewriteRe, &fanOut, priority, rateLimits, labels)
 		}),
 	}
 
 	cmd.Flags().StringVar(&backendRef, "backend-ref", "", "Reference to the backend this route targets")
-	cmd.Flags().StringVar(&pathPrefix, "path-prefix", "", "Path prefix to match on incoming requests")
-	cmd.Flags().StringVar(&path, "path", "", "Exact path to match on incoming requests")
-	cmd.Flags().StringVar(&sni, "sni", "", "Server Name Indication (SNI) value to match")
+	cmd.Flags().StringVar(&match.PathPrefix, "path-prefix", "", "Path prefix to match on incoming requests")
+	cmd.Flags().StringVar(&match.Path, "path", "", "Exact path to match on incoming requests")
+	cmd.Flags().StringVar(&match.PathRegex, "path-regex", "", "Regular expression to match against the path of incoming requests")
+	cmd.Flags().StringArrayVar(&match.Methods, "method", nil, "HTTP method to match (can be specified multiple times)")
+	cmd.Flags().StringVar(&match.Sni, "sni", "", "Server Name Indication (SNI) value to match")
+	cmd.Flags().StringVar(&namespace.Exact, "namespace", "", "Kubernetes namespace to match")
+	cmd.Flags().StringVar(&namespace.Prefix, "namespace-prefix", "", "Kubernetes namespace prefix to match")
+	cmd.Flags().StringVar(&namespace.Regex, "namespace-regex", "", "Regular expression to match against the Kubernetes namespace")
+	cmd.Flags().StringArrayVar(&match.ApiGroups, "api-group", nil, "Kubernetes API group to match, empty for the core group (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&match.Resources, "resource", nil, "Kubernetes resource to match, such as pods or pods/log (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&match.Verbs, "verb", nil, "Kubernetes verb to match, such as get, list or watch (can be specified multiple times)")
 	cmd.Flags().StringVar(&headerName, "header-name", "", "HTTP header name to match")
 	cmd.Flags().StringVar(&headerValue, "header-value", "", "HTTP header value to match (used together with --header-name)")
 	cmd.Flags().StringVar(&jwtClaim, "jwt-claim", "", "JWT claim name to match")
 	cmd.Flags().StringVar(&jwtValue, "jwt-value", "", "JWT claim value to match (used together with --jwt-claim)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Request timeout (e.g. 30s, 2m). Watches are not bounded by it. Zero means no timeout.")
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", 0, "Idle timeout of watches and exec, attach and port-forward sessions. Zero means the default of 4h.")
+	cmd.Flags().Uint64Var(&maxBodySize, "max-request-body-bytes", 0, "Maximum request body size in bytes. Zero means no limit.")
+	cmd.Flags().BoolVar(&rewrite.StripPrefix, "strip-prefix", false, "Strip the path prefix from requests before forwarding (used together with --path-prefix)")
+	cmd.Flags().StringVar(&rewrite.ReplacePrefix, "replace-prefix", "", "Replace the path prefix of requests with this value before forwarding (used together with --path-prefix)")
+	cmd.Flags().StringVar(&rewriteRe.Pattern, "rewrite-regex", "", "Regular expression matching the part of the path to rewrite")
+	cmd.Flags().StringVar(&rewriteRe.Substitution, "rewrite-substitution", "", "Substitution for --rewrite-regex, may refer to capture groups as $1 or ${name}")
+	cmd.Flags().StringArrayVar(&fanOut.BackendRefs, "fan-out", nil, "Backend to send list requests to and merge the results of (can be specified multiple times)")
+	cmd.Flags().StringVar(&fanOut.ClusterAnnotation, "cluster-annotation", "", "Annotation set to the backend of every item of fanned out lists. Defaults to multikube.io/cluster.")
+	cmd.Flags().Uint64Var(&fanOut.MaxListBytes, "fan-out-max-list-bytes", 0, "Maximum size in bytes of the list read from every fan-out backend. Zero means the default of 64MiB.")
+	cmd.Flags().Int32Var(&priority, "priority", 0, "Priority of the route, routes with higher priority are matched first")
+	cmd.Flags().StringArrayVar(&rateLimits, "rate-limit", nil, "Rate limit in KEY=RATE[:BURST] format, where KEY is one of route, user, group, client-ip or backend and RATE is in requests per second (can be specified multiple times)")
 	cmd.Flags().StringArrayVar(&labels, "label", nil, "Labels to attach in key=value format (can be specified multiple times)")
 
 	return cmd
@@ -78,7 +142,17 @@ func runCreateCreateCmd(
 	cmd *cobra.Command,
 	args []string,
 	cfg *client.Config,
-	backendRef, path, pathPrefix, sni, headerName, headerValue, jwtClaim, jwtValue string,
+	backendRef string,
+	match *routev1.Match,
+	namespace *routev1.NamespaceMatch,
+	headerName, headerValue, jwtClaim, jwtValue string,
+	timeout, idleTimeout time.Duration,
+	maxBodySize uint64,
+	rewrite *routev1.Rewrite,
+	rewriteRe *routev1.RegexRewrite,
+	fanOut *routev1.FanOut,
+	priority int32,
+	rateLimitStrs []string,
 	labelStrs []string,
 ) error {
 	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
@@ -90,6 +164,15 @@ func runCreateCreateCmd(
 
 	name := args[0]
 
+	rateLimits := make([]*routev1.RateLimit, 0, len(rateLimitStrs))
+	for _, str := range rateLimitStrs {
+		rl, err := parseRateLimit(str)
+		if err != nil {
+			return err
+		}
+		rateLimits = append(rateLimits, rl)
+	}
+
 	currentSrv, err := cfg.CurrentServer()
 	if err != nil {
 		logrus.Fatal(err)
@@ -104,10 +187,8 @@ func runCreateCreateCmd(
 		}
 	}()
 
-	match := &routev1.Match{
-		Sni:        sni,
-		Path:       path,
-		PathPrefix: pathPrefix,
+	if namespace.Exact != "" || namespace.Prefix != "" || namespace.Regex != "" {
+		match.Namespace = namespace
 	}
 
 	if headerName != "" || headerValue != "" {
@@ -130,12 +211,32 @@ func runCreateCreateCmd(
 			Labels: cmdutil.ConvertKVStringsToMap(labelStrs),
 		},
 		Config: &routev1.RouteConfig{
-			Name:       name,
-			BackendRef: backendRef,
-			Match:      match,
+			Name:                name,
+			BackendRef:          backendRef,
+			Match:               match,
+			MaxRequestBodyBytes: maxBodySize,
+			Priority:            priority,
+			RateLimits:          rateLimits,
 		},
 	}
 
+	if timeout > 0 {
+		route.Config.Timeout = durationpb.New(timeout)
+	}
+	if idleTimeout > 0 {
+		route.Config.IdleTimeout = durationpb.New(idleTimeout)
+	}
+
+	if rewriteRe.Pattern != "" {
+		rewrite.Regex = rewriteRe
+	}
+	if rewrite.StripPrefix || rewrite.ReplacePrefix != "" || rewrite.Regex != nil {
+		route.Config.Rewrite = rewrite
+	}
+	if len(fanOut.BackendRefs) > 0 {
+		route.Config.FanOut = fanOut
+	}
+
 	if err := c.RouteV1().Create(ctx, route); err != nil {
 		logrus.Fatalf("error creating route: %v", err)
 	}
@@ -144,3 +245,39 @@ func runCreateCreateCmd(
 
 	return nil
 }
+
+var rateLimitKeys = map[string]routev1.RateLimitKey{
+	"route":     routev1.RateLimitKey_RATE_LIMIT_KEY_ROUTE,
+	"user":      routev1.RateLimitKey_RATE_LIMIT_KEY_USER,
+	"group":     routev1.RateLimitKey_RATE_LIMIT_KEY_GROUP,
+	"client-ip": routev1.RateLimitKey_RATE_LIMIT_KEY_CLIENT_IP,
+	"backend":   routev1.RateLimitKey_RATE_LIMIT_KEY_BACKEND,
+}
+
+// parseRateLimit parses a rate limit in KEY=RATE[:BURST] format.
+func parseRateLimit(s string) (*routev1.RateLimit, error) {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid rate limit %q, expected KEY=RATE[:BURST]", s)
+	}
+	key, ok := rateLimitKeys[k]
+	if !ok {
+		return nil, fmt.Errorf("invalid rate limit %q, unknown key %q", s, k)
+	}
+
+	rate, burst, hasBurst := strings.Cut(v, ":")
+	rl := &routev1.RateLimit{Key: key}
+
+	var err error
+	if rl.RequestsPerSecond, err = strconv.ParseFloat(rate, 64); err != nil || rl.RequestsPerSecond <= 0 {
+		return nil, fmt.Errorf("invalid rate limit %q, rate must be a number greater than zero", s)
+	}
+	if hasBurst {
+		b, err := strconv.ParseUint(burst, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q, burst must be a positive integer", s)
+		}
+		rl.Burst = uint32(b)
+	}
+	return rl, nil
+}
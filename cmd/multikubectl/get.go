@@ -28,6 +28,8 @@ multikube get routes
 	cmd.AddCommand(newGetBackendCmd(cfg))
 	cmd.AddCommand(newGetRouteCmd(cfg))
 	cmd.AddCommand(newGetCertificateCmd(cfg))
+	cmd.AddCommand(newGetCredentialCmd(cfg))
+	cmd.AddCommand(newGetPolicyCmd(cfg))
 	cmd.AddCommand(newGetCACmd(cfg))
 
 	return cmd
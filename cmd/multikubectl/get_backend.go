@@ -12,6 +12,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel"
+
+	backendv1 "github.com/amimof/multikube/api/backend/v1"
+	backendclientv1 "github.com/amimof/multikube/pkg/client/backend/v1"
 )
 
 func newGetBackendCmd(cfg *client.Config) *cobra.Command {
@@ -108,11 +111,12 @@ func runListBackendsCmd(cmd *cobra.Command, cfg *client.Config) error {
 		logrus.Fatal(err)
 	}
 
-	_, _ = fmt.Fprintf(wr, "%s\t%s\t%s\n", "NAME", "GENERATION", "AGE")
+	_, _ = fmt.Fprintf(wr, "%s\t%s\t%s\t%s\n", "NAME", "GENERATION", "HEALTH", "AGE")
 	for _, c := range tasks {
-		_, _ = fmt.Fprintf(wr, "%s\t%d\t%s\n",
+		_, _ = fmt.Fprintf(wr, "%s\t%d\t%s\t%s\n",
 			c.GetMeta().GetName(),
 			c.GetMeta().GetGeneration(),
+			backendHealth(c),
 			cmdutil.FormatDuration(time.Since(c.GetMeta().GetCreated().AsTime())),
 		)
 	}
@@ -121,3 +125,15 @@ func runListBackendsCmd(cmd *cobra.Command, cfg *client.Config) error {
 
 	return nil
 }
+
+// backendHealth returns the health of a backend as reported by active health
+// checks, or "-" if its targets have never been probed.
+func backendHealth(b *backendv1.Backend) string {
+	if len(b.GetStatus().GetTargets()) == 0 {
+		return "-"
+	}
+	if b.GetStatus().GetHealthy() {
+		return backendclientv1.BackendHealthHealthy
+	}
+	return backendclientv1.BackendHealthUnhealthy
+}
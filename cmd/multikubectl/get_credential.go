@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
+	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/cmdutil"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+)
+
+func newGetCredentialCmd(cfg *client.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "credential [NAME]",
+		Aliases: []string{"cred", "creds", "credentials"},
+		Short:   "Get credentials",
+		Long:    `Retrieve and display credentials`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return runGetCredentialCmd(cmd, cfg, args[0])
+			}
+			return runListCredentialsCmd(cmd, cfg)
+		}),
+	}
+	return cmd
+}
+
+// runCredentialCmd lists all credentials registered with the multikube API server
+// and prints them as a formatted table to stdout.
+func runGetCredentialCmd(cmd *cobra.Command, cfg *client.Config, name string) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.credential.list")
+	defer span.End()
+
+	// Setup client
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+
+	lease, err := c.CredentialV1().Get(ctx, name)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	codec, err := cmdutil.CodecFor(outputFormat)
+	if err != nil {
+		logrus.Fatalf("error creating serializer: %v", err)
+	}
+
+	b, err := codec.Serialize(lease)
+	if err != nil {
+		logrus.Fatalf("error serializing: %v", err)
+	}
+
+	fmt.Printf("%s\n", string(b))
+
+	return nil
+}
+
+// runListCredentialsCmd lists all credentials registered with the multikube API server
+// and prints them as a formatted table to stdout.
+func runListCredentialsCmd(cmd *cobra.Command, cfg *client.Config) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.credential.list")
+	defer span.End()
+
+	// Setup client
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+	// Setup writer
+	wr := tabwriter.NewWriter(os.Stdout, 8, 8, 8, '\t', tabwriter.AlignRight)
+
+	tasks, err := c.CredentialV1().List(ctx)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	_, _ = fmt.Fprintf(wr, "%s\t%s\t%s\t%s\n", "NAME", "TYPE", "GENERATION", "AGE")
+	for _, c := range tasks {
+		_, _ = fmt.Fprintf(wr, "%s\t%s\t%d\t%s\n",
+			c.GetMeta().GetName(),
+			credentialType(c.GetConfig()),
+			c.GetMeta().GetGeneration(),
+			cmdutil.FormatDuration(time.Since(c.GetMeta().GetCreated().AsTime())),
+		)
+	}
+
+	_ = wr.Flush()
+
+	return nil
+}
+
+// credentialType returns a short description of the kind of credential.
+func credentialType(c *credentialv1.CredentialConfig) string {
+	switch {
+	case c.GetClientCertificateRef() != "":
+		return "client-certificate"
+	case c.GetToken() != "":
+		return "token"
+	case c.GetBasic() != nil:
+		return "basic"
+	case c.GetTokenFile() != "":
+		return "token-file"
+	case c.GetExec() != nil:
+		return "exec"
+	default:
+		return "unknown"
+	}
+}
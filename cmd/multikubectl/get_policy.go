@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
+	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/cmdutil"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+)
+
+func newGetPolicyCmd(cfg *client.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "policy [NAME]",
+		Aliases: []string{"pol", "policies"},
+		Short:   "Get policies",
+		Long:    `Retrieve and display policies`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: withConfig(func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return runGetPolicyCmd(cmd, cfg, args[0])
+			}
+			return runListPoliciesCmd(cmd, cfg)
+		}),
+	}
+	return cmd
+}
+
+// runPolicyCmd lists all policies registered with the multikube API server
+// and prints them as a formatted table to stdout.
+func runGetPolicyCmd(cmd *cobra.Command, cfg *client.Config, name string) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.policy.list")
+	defer span.End()
+
+	// Setup client
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+
+	lease, err := c.PolicyV1().Get(ctx, name)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	codec, err := cmdutil.CodecFor(outputFormat)
+	if err != nil {
+		logrus.Fatalf("error creating serializer: %v", err)
+	}
+
+	b, err := codec.Serialize(lease)
+	if err != nil {
+		logrus.Fatalf("error serializing: %v", err)
+	}
+
+	fmt.Printf("%s\n", string(b))
+
+	return nil
+}
+
+// runListPoliciesCmd lists all policies registered with the multikube API server
+// and prints them as a formatted table to stdout.
+func runListPoliciesCmd(cmd *cobra.Command, cfg *client.Config) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second*30)
+	defer cancel()
+
+	tracer := otel.Tracer("multikubectl")
+	ctx, span := tracer.Start(ctx, "multikubectl.policy.list")
+	defer span.End()
+
+	// Setup client
+	currentSrv, err := cfg.CurrentServer()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	c, err := client.New(currentSrv.Address, client.WithTLSConfigFromCfg(cfg))
+	if err != nil {
+		logrus.Fatalf("error setting up client: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Errorf("error closing client connection: %v", err)
+		}
+	}()
+	// Setup writer
+	wr := tabwriter.NewWriter(os.Stdout, 8, 8, 8, '\t', tabwriter.AlignRight)
+
+	tasks, err := c.PolicyV1().List(ctx)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	_, _ = fmt.Fprintf(wr, "%s\t%s\t%s\t%s\t%s\n", "NAME", "EFFECT", "ROUTES", "GENERATION", "AGE")
+	for _, c := range tasks {
+		routes := "*"
+		if len(c.GetConfig().GetRoutes()) > 0 {
+			routes = strings.Join(c.GetConfig().GetRoutes(), ",")
+		}
+		_, _ = fmt.Fprintf(wr, "%s\t%s\t%s\t%d\t%s\n",
+			c.GetMeta().GetName(),
+			policyEffect(c.GetConfig().GetEffect()),
+			routes,
+			c.GetMeta().GetGeneration(),
+			cmdutil.FormatDuration(time.Since(c.GetMeta().GetCreated().AsTime())),
+		)
+	}
+
+	_ = wr.Flush()
+
+	return nil
+}
+
+// policyEffect returns the effect of a policy in lower case.
+func policyEffect(e policyv1.Effect) string {
+	switch e {
+	case policyv1.Effect_EFFECT_ALLOW:
+		return "allow"
+	case policyv1.Effect_EFFECT_DENY:
+		return "deny"
+	case policyv1.Effect_EFFECT_UNSPECIFIED:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
@@ -2,9 +2,14 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/amimof/multikube/pkg/events"
 	"github.com/amimof/multikube/pkg/keys"
@@ -22,25 +27,26 @@ type BackendService struct {
 	Logger   logger.Logger
 }
 
-// func applyMaskedUpdateVolume(dst, src *backendv1.Status, mask *fieldmaskpb.FieldMask) error {
-// 	if mask == nil || len(mask.Paths) == 0 {
-// 		return status.Error(codes.InvalidArgument, "update_mask is required")
-// 	}
-//
-// 	for _, p := range mask.Paths {
-// 		switch p {
-// 		case "controllers":
-// 			if src.Controllers == nil {
-// 				continue
-// 			}
-// 			dst.Controllers = src.Controllers
-// 		default:
-// 			return fmt.Errorf("unknown mask path %q", p)
-// 		}
-// 	}
-//
-// 	return nil
-// }
+func applyMaskedUpdateBackendStatus(dst, src *backendv1.BackendStatus, mask *fieldmaskpb.FieldMask) error {
+	if mask == nil || len(mask.Paths) == 0 {
+		return status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	for _, p := range mask.Paths {
+		switch p {
+		case "healthy":
+			dst.Healthy = src.GetHealthy()
+		case "targets":
+			dst.Targets = src.GetTargets()
+		case "circuit":
+			dst.Circuit = src.GetCircuit()
+		default:
+			return fmt.Errorf("unknown mask path %q", p)
+		}
+	}
+
+	return nil
+}
 
 func (l *BackendService) Get(ctx context.Context, id keys.ID) (*backendv1.Backend, error) {
 	ctx, span := tracer.Start(ctx, "volume.Get", trace.WithSpanKind(trace.SpanKindServer))
@@ -162,34 +168,38 @@ func (l *BackendService) Patch(ctx context.Context, id keys.ID, patch *backendv1
 	return nil
 }
 
-// UpdateStatus implements volumes.VolumeServieClient.
-// func (l *VolumeService) UpdateStatus(ctx context.Context, id keys.ID, st *backendv1.Status, mask ...string) error {
-// 	l.mu.Lock()
-// 	defer l.mu.Unlock()
-//
-// 	ctx, span := tracer.Start(ctx, "volume.UpdateStatus")
-// 	defer span.End()
-//
-// 	// Get the existing volume before updating so we can compare specs
-// 	existingVolume, err := l.Repo.Get(ctx, id)
-// 	if err != nil {
-// 		return err
-// 	}
-//
-// 	// Apply mask safely
-// 	base := proto.Clone(existingVolume.Status).(*backendv1.Status)
-// 	if err := applyMaskedUpdateVolume(base, st, &fieldmaskpb.FieldMask{Paths: mask}); err != nil {
-// 		return status.Errorf(codes.InvalidArgument, "bad mask: %v", err)
-// 	}
-//
-// 	existingVolume.Status = base
-//
-// 	if _, err := l.Repo.Update(ctx, id, existingVolume); err != nil {
-// 		return err
-// 	}
-//
-// 	return nil
-// }
+// UpdateStatus updates the status fields of a backend given by mask. Status
+// updates never change the spec and therefore don't emit any events.
+func (l *BackendService) UpdateStatus(ctx context.Context, id keys.ID, st *backendv1.BackendStatus, mask ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ctx, span := tracer.Start(ctx, "backend.UpdateStatus")
+	defer span.End()
+
+	existing, err := l.Repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Apply mask safely
+	base := proto.Clone(existing.GetStatus()).(*backendv1.BackendStatus)
+	if base == nil {
+		base = &backendv1.BackendStatus{}
+	}
+	if err := applyMaskedUpdateBackendStatus(base, st, &fieldmaskpb.FieldMask{Paths: mask}); err != nil {
+		return status.Errorf(codes.InvalidArgument, "bad mask: %v", err)
+	}
+
+	existing.Status = base
+
+	if _, err := l.Repo.Update(ctx, id, existing); err != nil {
+		l.Logger.Error("error updating backend status", "error", err, "name", existing.GetMeta().GetName())
+		return err
+	}
+
+	return nil
+}
 
 func (l *BackendService) Update(ctx context.Context, id keys.ID, volume *backendv1.Backend) error {
 	ctx, span := tracer.Start(ctx, "volume.Update")
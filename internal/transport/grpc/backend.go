@@ -103,6 +103,25 @@ func (n *BackendService) Patch(ctx context.Context, req *backendv1.PatchRequest)
 	return &backendv1.PatchResponse{Backend: node}, nil
 }
 
+func (n *BackendService) UpdateStatus(ctx context.Context, req *backendv1.UpdateStatusRequest) (*backendv1.UpdateStatusResponse, error) {
+	uid, err := keys.FromUIDOrName(req.GetUid(), req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	err = n.app.UpdateStatus(ctx, uid, req.GetStatus(), req.GetUpdateMask().GetPaths()...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	node, err := n.app.Get(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &backendv1.UpdateStatusResponse{Backend: node}, nil
+}
+
 func NewBackendService(app *app.BackendService) *BackendService {
 	return &BackendService{app: app}
 }
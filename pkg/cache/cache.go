@@ -1,18 +1,61 @@
 package cache
 
 import (
+	"container/list"
+	"hash/fnv"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Defaults used by New.
+const (
+	DefaultTTL           = time.Second
+	DefaultMaxBytes      = 64 << 20
+	DefaultShards        = 16
+	DefaultSweepInterval = 10 * time.Second
+)
+
 // Cache is a simple key value store where the value is always a []byte.
 // It is mostly used in Multikube to store http responses in-memory in order to
 // cache requests and serve content to clients to decrease number of http calls
 // made to upstream servers.
+//
+// Items are spread over a number of shards, each guarded by its own lock. The
+// total size of all values is bounded by a maximum byte size. When it is
+// exceeded, least recently used items are evicted from the shards in turn.
+// Expired items are removed by a background sweeper that stops once the
+// cache is no longer referenced.
 type Cache struct {
-	Store map[string]*Item
-	TTL   time.Duration
+	// TTL is the time to live of items. Changing it affects items set
+	// afterwards.
+	TTL time.Duration
+
+	*store
+}
+
+// store holds the data of a Cache. It is kept separate so that the sweeper
+// does not keep the Cache reachable.
+type store struct {
+	name          string
+	maxBytes      int64
+	sweepInterval time.Duration
+	shards        []*shard
+
+	bytes     atomic.Int64
+	items     atomic.Int64
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	nextEvict atomic.Uint64
+
+	metrics *storeMetrics
+}
+
+type shard struct {
 	mux   sync.Mutex
+	items map[string]*list.Element
+	lru   *list.List
 }
 
 // Item represents a unit stored in the cache
@@ -23,90 +66,149 @@ type Item struct {
 	created time.Time
 }
 
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithTTL sets the time to live of items.
+func WithTTL(d time.Duration) Option {
+	return func(c *Cache) {
+		c.TTL = d
+	}
+}
+
+// WithMaxBytes bounds the total size of all values to n bytes. A value of zero
+// or less disables the bound.
+func WithMaxBytes(n int64) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithShards sets the number of shards.
+func WithShards(n int) Option {
+	return func(c *Cache) {
+		if n > 0 {
+			c.shards = make([]*shard, n)
+		}
+	}
+}
+
+// WithSweepInterval sets how often expired items are removed.
+func WithSweepInterval(d time.Duration) Option {
+	return func(c *Cache) {
+		c.sweepInterval = d
+	}
+}
+
+// WithName exports metrics for the cache labeled with name, usually the name
+// of the backend whose responses are cached.
+func WithName(name string) Option {
+	return func(c *Cache) {
+		c.name = name
+	}
+}
+
 // ListKeys returns the keys of all items in the cache as a string array
 func (c *Cache) ListKeys() []string {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-
-	keys := make([]string, 0, len(c.Store))
-	for key := range c.Store {
-		keys = append(keys, key)
+	keys := make([]string, 0, c.Len())
+	for _, sh := range c.shards {
+		sh.mux.Lock()
+		for key := range sh.items {
+			keys = append(keys, key)
+		}
+		sh.mux.Unlock()
 	}
 	return keys
 }
 
 // Get returns an item from the cache by key
 func (c *Cache) Get(key string) *Item {
-	c.mux.Lock()
-	defer c.mux.Unlock()
+	sh := c.shard(key)
+	sh.mux.Lock()
 
-	if !c.Exists(key) {
+	e, ok := sh.items[key]
+	if !ok {
+		sh.mux.Unlock()
+		c.miss()
 		return nil
 	}
 
-	item := c.Store[key]
-	if !item.expires.IsZero() && item.Age() > c.TTL {
+	item := e.Value.(*Item)
+	if item.expired(time.Now()) {
 		// Item age exceeded time to live
-		delete(c.Store, key)
+		c.remove(sh, e)
+		sh.mux.Unlock()
+		c.miss()
 		return nil
 	}
+
+	sh.lru.MoveToFront(e)
+	sh.mux.Unlock()
+	c.hit()
 	return item
 }
 
-// Set instantiates and allocates a key in the cache and overwrites any previously set item
+// Set instantiates and allocates a key in the cache and overwrites any previously set item.
+// Items larger than the maximum byte size of the cache are returned but not stored.
 func (c *Cache) Set(key string, val []byte) *Item {
-	c.mux.Lock()
-	defer c.mux.Unlock()
+	now := time.Now()
+	item := &Item{
+		Key:     key,
+		Value:   val,
+		expires: now.Add(c.TTL),
+		created: now,
+	}
+
+	sh := c.shard(key)
+	sh.mux.Lock()
 
 	// Delete item if already exists in the cache
-	if c.Exists(key) {
-		delete(c.Store, key)
+	if e, ok := sh.items[key]; ok {
+		c.remove(sh, e)
 	}
 
-	item := &Item{
-		Key:     key,
-		Value:   val,
-		expires: time.Now().Add(c.TTL),
-		created: time.Now(),
+	if c.maxBytes > 0 && int64(item.Bytes()) > c.maxBytes {
+		sh.mux.Unlock()
+		return item
 	}
 
-	c.Store[key] = item
+	sh.items[key] = sh.lru.PushFront(item)
+	c.bytes.Add(int64(item.Bytes()))
+	c.items.Add(1)
+	sh.mux.Unlock()
+	c.metrics.changed(1, int64(item.Bytes()))
+
+	c.evict()
 	return item
 }
 
 // Delete removes an item by key
 func (c *Cache) Delete(key string) {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	delete(c.Store, key)
+	sh := c.shard(key)
+	sh.mux.Lock()
+	if e, ok := sh.items[key]; ok {
+		c.remove(sh, e)
+	}
+	sh.mux.Unlock()
 }
 
 // Exists returns true if an item with the given exists is non-nil. Otherwise returns false
 func (c *Cache) Exists(key string) bool {
-	if _, ok := c.Store[key]; ok {
-		return true
-	}
-	return false
+	sh := c.shard(key)
+	sh.mux.Lock()
+	defer sh.mux.Unlock()
+	_, ok := sh.items[key]
+	return ok
 }
 
 // Len returns the number of items stored in cache
 func (c *Cache) Len() int {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-
-	return len(c.Store)
+	return int(c.items.Load())
 }
 
 // Size return the sum of all bytes in the cache
 func (c *Cache) Size() int {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-
-	l := 0
-	for _, val := range c.Store {
-		l += val.Bytes()
-	}
-	return l
+	return int(c.bytes.Load())
 }
 
 // Age returns the duration elapsed since creation
@@ -124,10 +226,119 @@ func (i *Item) Bytes() int {
 	return len(i.Value)
 }
 
+func (i *Item) expired(now time.Time) bool {
+	return !now.Before(i.expires)
+}
+
+func (s *store) shard(key string) *shard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// remove removes e from sh. The lock of sh must be held.
+func (s *store) remove(sh *shard, e *list.Element) {
+	item := sh.lru.Remove(e).(*Item)
+	delete(sh.items, item.Key)
+	s.bytes.Add(-int64(item.Bytes()))
+	s.items.Add(-1)
+	s.metrics.changed(-1, -int64(item.Bytes()))
+}
+
+// evict removes least recently used items until the cache fits within its
+// maximum byte size. Shards are visited in turn so that eviction is spread
+// evenly, which approximates a global LRU without a global lock.
+func (s *store) evict() {
+	if s.maxBytes <= 0 {
+		return
+	}
+	for empty := 0; s.bytes.Load() > s.maxBytes && empty < len(s.shards); {
+		sh := s.shards[s.nextEvict.Add(1)%uint64(len(s.shards))]
+		sh.mux.Lock()
+		e := sh.lru.Back()
+		if e == nil {
+			sh.mux.Unlock()
+			empty++
+			continue
+		}
+		s.remove(sh, e)
+		sh.mux.Unlock()
+		empty = 0
+		s.metrics.evicted()
+	}
+}
+
+// sweep removes expired items every sweepInterval until stop is closed.
+func (s *store) sweep(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.removeExpired(now)
+		}
+	}
+}
+
+func (s *store) removeExpired(now time.Time) {
+	for _, sh := range s.shards {
+		sh.mux.Lock()
+		for e := sh.lru.Back(); e != nil; {
+			prev := e.Prev()
+			if e.Value.(*Item).expired(now) {
+				s.remove(sh, e)
+			}
+			e = prev
+		}
+		sh.mux.Unlock()
+	}
+}
+
+func (s *store) hit() {
+	s.hits.Add(1)
+	s.metrics.lookedUp(s)
+}
+
+func (s *store) miss() {
+	s.misses.Add(1)
+	s.metrics.lookedUp(s)
+}
+
 // New return a new empty cache instance
-func New() *Cache {
-	return &Cache{
-		Store: make(map[string]*Item),
-		TTL:   time.Second * 1,
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		TTL: DefaultTTL,
+		store: &store{
+			maxBytes:      DefaultMaxBytes,
+			sweepInterval: DefaultSweepInterval,
+			shards:        make([]*shard, DefaultShards),
+		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for i := range c.shards {
+		c.shards[i] = &shard{
+			items: make(map[string]*list.Element),
+			lru:   list.New(),
+		}
+	}
+
+	if c.name != "" {
+		c.metrics = newStoreMetrics(c.name)
+	}
+
+	stop := make(chan struct{})
+	if c.sweepInterval > 0 {
+		go c.sweep(stop)
+	}
+	runtime.AddCleanup(c, func(s *store) {
+		close(stop)
+		s.metrics.release(s)
+	}, c.store)
+
+	return c
 }
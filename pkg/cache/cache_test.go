@@ -1,7 +1,10 @@
 package cache
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 )
 
 var key = "somekey"
@@ -113,3 +116,90 @@ func TestCacheLen(t *testing.T) {
 	}
 
 }
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := New(WithTTL(time.Minute), WithMaxBytes(3), WithShards(1))
+	cache.Set("A", []byte("a"))
+	cache.Set("B", []byte("b"))
+	cache.Set("C", []byte("c"))
+
+	// Touch A so that B is the least recently used
+	cache.Get("A")
+	cache.Set("D", []byte("d"))
+
+	if cache.Exists("B") {
+		t.Fatalf("Expected least recently used item %s to be evicted", "B")
+	}
+	for _, k := range []string{"A", "C", "D"} {
+		if !cache.Exists(k) {
+			t.Fatalf("Expected item %s to be in the cache", k)
+		}
+	}
+	if cache.Size() != 3 {
+		t.Fatalf("Expected cache size to be %d but got %d", 3, cache.Size())
+	}
+}
+
+func TestCacheMaxBytesAcrossShards(t *testing.T) {
+	cache := New(WithTTL(time.Minute), WithMaxBytes(100), WithShards(4))
+	for i := range 50 {
+		cache.Set(fmt.Sprintf("key-%d", i), []byte("0123456789"))
+	}
+	if cache.Size() > 100 {
+		t.Fatalf("Expected cache size to be at most %d but got %d", 100, cache.Size())
+	}
+	if cache.Len() != 10 {
+		t.Fatalf("Expected cache length to be %d but got %d", 10, cache.Len())
+	}
+}
+
+func TestCacheItemLargerThanMaxBytes(t *testing.T) {
+	cache := New(WithMaxBytes(4))
+	cache.Set(key, []byte(val))
+	if cache.Exists(key) {
+		t.Fatalf("Item with key %s should not be stored", key)
+	}
+}
+
+func TestCacheSweepsExpiredItems(t *testing.T) {
+	cache := New(WithTTL(10*time.Millisecond), WithSweepInterval(10*time.Millisecond))
+	cache.Set(key, []byte(val))
+
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected expired item with key %s to be swept", key)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if cache.Size() != 0 {
+		t.Fatalf("Expected cache size to be %d but got %d", 0, cache.Size())
+	}
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	cache := New(WithTTL(time.Minute), WithMaxBytes(64))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				k := fmt.Sprintf("key-%d-%d", i, j%20)
+				cache.Set(k, []byte("value"))
+				cache.Get(k)
+				if j%7 == 0 {
+					cache.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cache.Size() > 64 {
+		t.Fatalf("Expected cache size to be at most %d but got %d", 64, cache.Size())
+	}
+	if cache.Size() != cache.Len()*len("value") {
+		t.Fatalf("Expected cache size %d to match length %d", cache.Size(), cache.Len())
+	}
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheItems = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_cache_items",
+		Help: "A gauge for the number of items in the cache.",
+	},
+		[]string{"backend"},
+	)
+	cacheBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_cache_bytes",
+		Help: "A gauge for the number of bytes of values in the cache.",
+	},
+		[]string{"backend"},
+	)
+	cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_cache_evictions_total",
+		Help: "A counter for items evicted to keep the cache within its maximum size.",
+	},
+		[]string{"backend"},
+	)
+	cacheHitRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_cache_hit_ratio",
+		Help: "A gauge for the ratio of lookups that found an item in the cache.",
+	},
+		[]string{"backend"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheItems,
+		cacheBytes,
+		cacheEvictions,
+		cacheHitRatio,
+	)
+}
+
+// storeMetrics reports the metrics of a store. Items and bytes are reported as
+// deltas, when they change, so that several caches of the same backend, such
+// as the one being replaced and its replacement, add up instead of
+// overwriting each other. A nil storeMetrics reports nothing.
+type storeMetrics struct {
+	items     prometheus.Gauge
+	bytes     prometheus.Gauge
+	evictions prometheus.Counter
+	hitRatio  prometheus.Gauge
+}
+
+func newStoreMetrics(name string) *storeMetrics {
+	return &storeMetrics{
+		items:     cacheItems.WithLabelValues(name),
+		bytes:     cacheBytes.WithLabelValues(name),
+		evictions: cacheEvictions.WithLabelValues(name),
+		hitRatio:  cacheHitRatio.WithLabelValues(name),
+	}
+}
+
+// changed reports that items and bytes were added to the store, or removed
+// if negative.
+func (m *storeMetrics) changed(items, bytes int64) {
+	if m == nil {
+		return
+	}
+	m.items.Add(float64(items))
+	m.bytes.Add(float64(bytes))
+}
+
+// lookedUp reports the hit ratio of s after a lookup.
+func (m *storeMetrics) lookedUp(s *store) {
+	if m == nil {
+		return
+	}
+	hits, misses := s.hits.Load(), s.misses.Load()
+	if total := hits + misses; total > 0 {
+		m.hitRatio.Set(float64(hits) / float64(total))
+	}
+}
+
+func (m *storeMetrics) evicted() {
+	if m == nil {
+		return
+	}
+	m.evictions.Inc()
+}
+
+// release withdraws the items and bytes of s, used once the store is gone.
+func (m *storeMetrics) release(s *store) {
+	if m == nil {
+		return
+	}
+	m.changed(-s.items.Load(), -s.bytes.Load())
+}
@@ -5,6 +5,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/amimof/multikube/pkg/client/version"
 	"github.com/amimof/multikube/pkg/errs"
@@ -41,6 +42,7 @@ type ClientV1 interface {
 	Get(context.Context, string) (*backendv1.Backend, error)
 	Delete(context.Context, string) error
 	List(context.Context, ...labels.Label) ([]*backendv1.Backend, error)
+	UpdateStatus(context.Context, string, *backendv1.BackendStatus, ...string) error
 }
 
 type clientV1 struct {
@@ -149,6 +151,28 @@ func (c *clientV1) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (c *clientV1) UpdateStatus(ctx context.Context, id string, st *backendv1.BackendStatus, mask ...string) error {
+	tracer := otel.Tracer("client-v1")
+	ctx, span := tracer.Start(ctx, "client.backend.UpdateStatus")
+	defer span.End()
+
+	uid, err := keys.ParseStr(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.UpdateStatus(ctx, &backendv1.UpdateStatusRequest{
+		Uid:        uid.UUIDStr(),
+		Name:       uid.NameStr(),
+		Status:     st,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: mask},
+	})
+	if err != nil {
+		return errs.ToStatus(err)
+	}
+	return nil
+}
+
 func NewClientV1(opts ...CreateOption) ClientV1 {
 	c := &clientV1{}
 	for _, opt := range opts {
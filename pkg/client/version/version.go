@@ -7,6 +7,8 @@ import (
 	backendv1 "github.com/amimof/multikube/api/backend/v1"
 	cav1 "github.com/amimof/multikube/api/ca/v1"
 	certv1 "github.com/amimof/multikube/api/certificate/v1"
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
 )
 
@@ -14,6 +16,8 @@ var apiVersionByFullName = map[protoreflect.FullName]string{
 	"backend.v1.Backend":         "backend/v1",
 	"ca.v1.CertificateAuthority": "ca/v1",
 	"certificate.v1.Certificate": "certificate/v1",
+	"credential.v1.Credential":   "credential/v1",
+	"policy.v1.Policy":           "policy/v1",
 	"route.v1.Route":             "route/v1",
 }
 
@@ -21,6 +25,8 @@ var (
 	VersionBackend              = Version((&backendv1.Backend{}))
 	VersionCertificateAuthority = Version((&cav1.CertificateAuthority{}))
 	VersionCertificate          = Version((&certv1.Certificate{}))
+	VersionCredential           = Version((&credentialv1.Credential{}))
+	VersionPolicy               = Version((&policyv1.Policy{}))
 	VersionRoute                = Version((&routev1.Route{}))
 )
 
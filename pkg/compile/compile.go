@@ -1,20 +1,30 @@
 package compile
 
 import (
+	"cmp"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/textproto"
 	"net/url"
+	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	backendv1 "github.com/amimof/multikube/api/backend/v1"
 	cav1 "github.com/amimof/multikube/api/ca/v1"
 	certificatev1 "github.com/amimof/multikube/api/certificate/v1"
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
+	metav1 "github.com/amimof/multikube/api/meta/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
+	"github.com/amimof/multikube/pkg/cache"
 	proxy "github.com/amimof/multikube/pkg/proxyv2"
 )
 
@@ -25,17 +35,57 @@ type State struct {
 	Routes                 map[string]*routev1.Route
 	Certificates           map[string]*certificatev1.Certificate
 	CertificateAuthorities map[string]*cav1.CertificateAuthority
+	Credentials            map[string]*credentialv1.Credential
+	Policies               map[string]*policyv1.Policy
 }
 
 // Compiler compiles a State into a proxy Runtime.
 // It holds no shared state and each Compile call is self-contained.
 type Compiler struct {
 	version atomic.Uint64
+
+	// execCommands are the commands exec credentials may run.
+	execCommands map[string]struct{}
+	// tokenFileDirs are the directories token files may be read from.
+	tokenFileDirs []string
+}
+
+// CompilerOption configures a Compiler.
+type CompilerOption func(*Compiler)
+
+// WithExecCommands allows exec credentials to run commands. Credentials
+// running any other command fail to compile. Exec credentials are rejected
+// unless this option is given, since they run as the multikube process.
+func WithExecCommands(commands ...string) CompilerOption {
+	return func(c *Compiler) {
+		if c.execCommands == nil {
+			c.execCommands = make(map[string]struct{}, len(commands))
+		}
+		for _, cmd := range commands {
+			c.execCommands[cmd] = struct{}{}
+		}
+	}
+}
+
+// WithTokenFileDirs allows credentials to read token files in dirs and their
+// subdirectories. Credentials reading any other file fail to compile. Token
+// files are rejected unless this option is given, since they are read as the
+// multikube process.
+func WithTokenFileDirs(dirs ...string) CompilerOption {
+	return func(c *Compiler) {
+		for _, dir := range dirs {
+			c.tokenFileDirs = append(c.tokenFileDirs, filepath.Clean(dir))
+		}
+	}
 }
 
 // NewCompiler returns a new Compiler2.
-func NewCompiler() *Compiler {
-	return &Compiler{}
+func NewCompiler(opts ...CompilerOption) *Compiler {
+	c := &Compiler{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Compile converts the contents of a State into a *proxy.RuntimeConfig that
@@ -53,8 +103,14 @@ func (c *Compiler) Compile(st *State) (*proxy.RuntimeConfig, error) {
 		return nil, fmt.Errorf("compile CAs: %w", err)
 	}
 
+	// compile credentials; they may reference certificates.
+	creds, err := c.compileCredentials(st.Credentials, tlsCerts)
+	if err != nil {
+		return nil, fmt.Errorf("compile credentials: %w", err)
+	}
+
 	// compile backends into BackendRuntimes and per-backend Forwarders.
-	backends, forwarders, err := compileBackends2(st.Backends, caPools, tlsCerts)
+	backends, forwarders, err := compileBackends2(st.Backends, caPools, tlsCerts, creds)
 	if err != nil {
 		return nil, fmt.Errorf("compile backends: %w", err)
 	}
@@ -65,10 +121,18 @@ func (c *Compiler) Compile(st *State) (*proxy.RuntimeConfig, error) {
 		return nil, fmt.Errorf("compile routes: %w", err)
 	}
 
+	// compile policies into an Authorizer and Admission.
+	authorizer, admission, err := compilePolicies(st.Policies)
+	if err != nil {
+		return nil, fmt.Errorf("compile policies: %w", err)
+	}
+
 	return &proxy.RuntimeConfig{
-		Version:  c.version.Add(1),
-		Backends: backends,
-		Routes:   routes,
+		Version:    c.version.Add(1),
+		Backends:   backends,
+		Routes:     routes,
+		Authorizer: authorizer,
+		Admission:  admission,
 	}, nil
 }
 
@@ -144,24 +208,168 @@ func compileCert(cert *certificatev1.Certificate) (tls.Certificate, error) {
 	return tlsCert, nil
 }
 
-// compileBackends2 builds a BackendRuntime and a Forwarder for every healthy
-// backend. Unhealthy backends are silently skipped.
+func (c *Compiler) compileCredentials(creds map[string]*credentialv1.Credential, tlsCerts map[string]tls.Certificate) (map[string]proxy.RequestAuthInjector, error) {
+	out := make(map[string]proxy.RequestAuthInjector, len(creds))
+	for name, cred := range creds {
+		inj, err := c.compileCredential(cred, tlsCerts)
+		if err != nil {
+			return nil, fmt.Errorf("credential %q: %w", name, err)
+		}
+		out[name] = inj
+	}
+	return out, nil
+}
+
+var policyEffects = map[policyv1.Effect]proxy.PolicyEffect{
+	policyv1.Effect_EFFECT_ALLOW: proxy.PolicyEffectAllow,
+	policyv1.Effect_EFFECT_DENY:  proxy.PolicyEffectDeny,
+}
+
+// compilePolicies builds the Authorizer of the policies with an effect and
+// the Admission of those with validations or a mutation, ordered by name.
+// Either is nil if no policy belongs to it.
+func compilePolicies(policies map[string]*policyv1.Policy) (*proxy.Authorizer, *proxy.Admission, error) {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		authorizer *proxy.Authorizer
+		admission  *proxy.Admission
+	)
+	for _, name := range names {
+		p, err := compilePolicy(policies[name])
+		if err != nil {
+			return nil, nil, fmt.Errorf("policy %q: %w", name, err)
+		}
+		if p.Effect != 0 {
+			if authorizer == nil {
+				authorizer = &proxy.Authorizer{}
+			}
+			authorizer.Policies = append(authorizer.Policies, p)
+		}
+		if len(p.Validations) > 0 || p.Mutation != nil {
+			if admission == nil {
+				admission = &proxy.Admission{}
+			}
+			admission.Policies = append(admission.Policies, p)
+		}
+	}
+	return authorizer, admission, nil
+}
+
+// compilePolicy builds a proxy Policy from a Policy object.
+func compilePolicy(policy *policyv1.Policy) (*proxy.Policy, error) {
+	cfg := policy.GetConfig()
+	effect, ok := policyEffects[cfg.GetEffect()]
+	if !ok && cfg.GetEffect() != policyv1.Effect_EFFECT_UNSPECIFIED {
+		return nil, fmt.Errorf("unsupported effect %s", cfg.GetEffect())
+	}
+	p, err := proxy.NewPolicy(policy.GetMeta().GetName(), effect, cfg.GetExpression())
+	if err != nil {
+		return nil, fmt.Errorf("expression: %w", err)
+	}
+	p.Message = cfg.GetMessage()
+	p.Routes = compileOptionalSet(cfg.GetRoutes())
+
+	for i, v := range cfg.GetValidations() {
+		validation, err := proxy.NewValidation(v.GetExpression(), v.GetMessage())
+		if err != nil {
+			return nil, fmt.Errorf("validation %d: %w", i, err)
+		}
+		p.Validations = append(p.Validations, validation)
+	}
+	if m := cfg.GetMutation(); len(m.GetLabels()) > 0 || len(m.GetAnnotations()) > 0 {
+		p.Mutation = &proxy.Mutation{Labels: m.GetLabels(), Annotations: m.GetAnnotations()}
+	}
+
+	if p.Effect == 0 && len(p.Validations) == 0 && p.Mutation == nil {
+		return nil, errors.New("policy has no effect, validations or mutation")
+	}
+	return p, nil
+}
+
+// compileCredential builds the RequestAuthInjector of a Credential object.
+func (c *Compiler) compileCredential(cred *credentialv1.Credential, tlsCerts map[string]tls.Certificate) (proxy.RequestAuthInjector, error) {
+	cfg := cred.GetConfig()
+	switch {
+	case cfg.GetClientCertificateRef() != "":
+		ref := cfg.GetClientCertificateRef()
+		cert, ok := tlsCerts[ref]
+		if !ok {
+			return nil, fmt.Errorf("client_certificate_ref %q not found", ref)
+		}
+		return &proxy.ClientCertificateAuth{Certificate: cert}, nil
+	case cfg.GetToken() != "":
+		return &proxy.BearerTokenAuth{Token: cfg.GetToken()}, nil
+	case cfg.GetBasic() != nil:
+		return &proxy.BasicAuth{Username: cfg.GetBasic().GetUsername(), Password: cfg.GetBasic().GetPassword()}, nil
+	case cfg.GetTokenFile() != "":
+		if !c.tokenFileAllowed(cfg.GetTokenFile()) {
+			return nil, fmt.Errorf("token_file %q is not in an allowed directory", cfg.GetTokenFile())
+		}
+		return &proxy.FileTokenAuth{Path: cfg.GetTokenFile()}, nil
+	case cfg.GetExec() != nil:
+		return c.compileExec(cfg.GetExec())
+	default:
+		return nil, fmt.Errorf("neither client certificate, token, basic auth, token file nor exec provided")
+	}
+}
+
+// tokenFileAllowed returns true if path is an absolute path within one of
+// the directories allowed by WithTokenFileDirs.
+func (c *Compiler) tokenFileAllowed(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	for _, dir := range c.tokenFileDirs {
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileExec builds the ExecAuth of an exec credential, whose command must
+// be allowed by WithExecCommands.
+func (c *Compiler) compileExec(e *credentialv1.ExecConfig) (*proxy.ExecAuth, error) {
+	if e.GetCommand() == "" {
+		return nil, fmt.Errorf("exec: command is required")
+	}
+	if _, ok := c.execCommands[e.GetCommand()]; !ok {
+		return nil, fmt.Errorf("exec: command %q is not allowed", e.GetCommand())
+	}
+
+	env := make([]string, 0, len(e.GetEnv()))
+	for name, value := range e.GetEnv() {
+		env = append(env, name+"="+value)
+	}
+	sort.Strings(env)
+
+	return &proxy.ExecAuth{
+		Command:       e.GetCommand(),
+		Args:          e.GetArgs(),
+		Env:           env,
+		APIVersion:    e.GetApiVersion(),
+		RefreshBefore: e.GetRefreshBefore().AsDuration(),
+	}, nil
+}
+
+// compileBackends2 builds a BackendRuntime and a Forwarder for every backend.
 func compileBackends2(
 	backends map[string]*backendv1.Backend,
 	caPools map[string]*x509.CertPool,
 	tlsCerts map[string]tls.Certificate,
+	creds map[string]proxy.RequestAuthInjector,
 ) (map[string]*proxy.BackendRuntime, map[string]*proxy.Forwarder, error) {
 	out := make(map[string]*proxy.BackendRuntime, len(backends))
 	fwds := make(map[string]*proxy.Forwarder, len(backends))
 
 	for name, be := range backends {
-
-		// TODO: Check for healthyness but only if it's cheap
-		// if !be.GetStatus().GetHealthy() {
-		// 	continue
-		// }
-
-		br, fwd, err := compileBackend2(be, caPools, tlsCerts)
+		br, fwd, err := compileBackend2(be, caPools, tlsCerts, creds)
 		if err != nil {
 			return nil, nil, fmt.Errorf("backend %q: %w", name, err)
 		}
@@ -179,12 +387,8 @@ func compileBackend2(
 	be *backendv1.Backend,
 	caPools map[string]*x509.CertPool,
 	tlsCerts map[string]tls.Certificate,
+	creds map[string]proxy.RequestAuthInjector,
 ) (*proxy.BackendRuntime, *proxy.Forwarder, error) {
-	serverURL, err := url.Parse(be.GetConfig().GetServer())
-	if err != nil {
-		return nil, nil, fmt.Errorf("parsing server URL %q: %w", be.GetConfig().GetServer(), err)
-	}
-
 	tlsCfg := &tls.Config{
 		InsecureSkipVerify: be.GetConfig().GetInsecureSkipTlsVerify(), //nolint:gosec // user-controlled
 	}
@@ -197,34 +401,340 @@ func compileBackend2(
 		tlsCfg.RootCAs = pool
 	}
 
-	if ref := be.GetConfig().GetAuthRef(); ref != "" {
-		cert, ok := tlsCerts[ref]
-		if !ok {
-			return nil, nil, fmt.Errorf("auth_ref %q not found", ref)
-		}
-		tlsCfg.Certificates = []tls.Certificate{cert}
+	authInjector, err := resolveAuthRef(be.GetConfig().GetAuthRef(), tlsCerts, creds)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t, ok := authInjector.(proxy.TLSAuthInjector); ok {
+		t.ConfigureTLS(tlsCfg)
 	}
 
 	var cacheTTL time.Duration
-	if pb := be.GetConfig().GetCacheTtl(); pb != nil {
+	var respCache *cache.Cache
+	if pb := be.GetConfig().GetCacheTtl(); pb != nil && pb.AsDuration() > 0 {
 		cacheTTL = pb.AsDuration()
+		opts := []cache.Option{cache.WithTTL(cacheTTL), cache.WithName(be.GetMeta().GetName())}
+		if n := be.GetConfig().GetCacheMaxBytes(); n > 0 {
+			opts = append(opts, cache.WithMaxBytes(int64(min(n, math.MaxInt64))))
+		}
+		respCache = cache.New(opts...)
+	}
+
+	reqHeaders, err := compileHeaderMutation(be.GetConfig().GetRequestHeaders())
+	if err != nil {
+		return nil, nil, fmt.Errorf("request_headers: %w", err)
+	}
+	respHeaders, err := compileHeaderMutation(be.GetConfig().GetResponseHeaders())
+	if err != nil {
+		return nil, nil, fmt.Errorf("response_headers: %w", err)
+	}
+
+	flowControl, err := compileFlowControl(be.GetMeta().GetName(), be.GetConfig().GetFlowControl())
+	if err != nil {
+		return nil, nil, fmt.Errorf("flow_control: %w", err)
 	}
 
-	transport := buildTLSTransport(tlsCfg)
+	transport := buildTLSTransport(tlsCfg, int(be.GetConfig().GetFlowControl().GetMaxInFlight()))
 	fwd := proxy.NewForwarder(transport)
 
 	br := &proxy.BackendRuntime{
-		Name:      be.GetMeta().GetName(),
-		URL:       serverURL,
-		CacheTTL:  cacheTTL,
-		TLSConfig: tlsCfg,
-		Transport: transport,
-		// AuthInjector left nil until an implementation exists.
+		Name:        be.GetMeta().GetName(),
+		Labels:      be.GetMeta().GetLabels(),
+		CacheTTL:    cacheTTL,
+		Cache:       respCache,
+		HealthCheck: compileHealthCheck(be.GetConfig().GetHealthCheck()),
+
+		OutlierDetection: compileOutlierDetection(be.GetConfig().GetOutlierDetection()),
+		CircuitBreaker:   compileCircuitBreaker(be.GetConfig().GetCircuitBreaker()),
+		FlowControl:      flowControl,
+
+		TLSConfig:     tlsCfg,
+		Transport:     transport,
+		AuthInjector:  authInjector,
+		Impersonation: impersonationModes[be.GetConfig().GetImpersonation()],
+
+		RequestHeaders:  reqHeaders,
+		ResponseHeaders: respHeaders,
+		Decoration:      compileDecoration(be),
+	}
+
+	targets, err := compileTargets(be, br)
+	if err != nil {
+		return nil, nil, err
 	}
+	br.Targets = targets
 
 	return br, fwd, nil
 }
 
+// resolveAuthRef returns the injector of the credential named ref. A ref
+// naming a certificate, as used before credentials existed, is presented as
+// client certificate. It returns nil if ref is empty.
+func resolveAuthRef(ref string, tlsCerts map[string]tls.Certificate, creds map[string]proxy.RequestAuthInjector) (proxy.RequestAuthInjector, error) {
+	if ref == "" {
+		return nil, nil
+	}
+	if inj, ok := creds[ref]; ok {
+		return inj, nil
+	}
+	if cert, ok := tlsCerts[ref]; ok {
+		return &proxy.ClientCertificateAuth{Certificate: cert}, nil
+	}
+	return nil, fmt.Errorf("auth_ref %q not found", ref)
+}
+
+// compileHealthCheck applies defaults to a HealthCheck. It returns nil if
+// health checking is not configured.
+func compileHealthCheck(hc *backendv1.HealthCheck) *proxy.HealthCheckRuntime {
+	if hc == nil {
+		return nil
+	}
+
+	out := &proxy.HealthCheckRuntime{
+		Path:               hc.GetPath(),
+		Interval:           hc.GetInterval().AsDuration(),
+		Timeout:            hc.GetTimeout().AsDuration(),
+		HealthyThreshold:   int(hc.GetHealthyThreshold()),
+		UnhealthyThreshold: int(hc.GetUnhealthyThreshold()),
+	}
+
+	if out.Path == "" {
+		out.Path = proxy.DefaultHealthCheckPath
+	}
+	if !strings.HasPrefix(out.Path, "/") {
+		out.Path = "/" + out.Path
+	}
+	if out.Interval <= 0 {
+		out.Interval = proxy.DefaultHealthCheckInterval
+	}
+	if out.Timeout <= 0 {
+		out.Timeout = proxy.DefaultHealthCheckTimeout
+	}
+	if out.HealthyThreshold <= 0 {
+		out.HealthyThreshold = proxy.DefaultHealthyThreshold
+	}
+	if out.UnhealthyThreshold <= 0 {
+		out.UnhealthyThreshold = proxy.DefaultUnhealthyThreshold
+	}
+
+	return out
+}
+
+// compileOutlierDetection applies defaults to an OutlierDetection. It returns
+// nil if outlier detection is not configured.
+func compileOutlierDetection(od *backendv1.OutlierDetection) *proxy.OutlierDetectionRuntime {
+	if od == nil {
+		return nil
+	}
+
+	out := &proxy.OutlierDetectionRuntime{
+		ConsecutiveErrors: int(od.GetConsecutiveErrors()),
+		BaseEjectionTime:  od.GetBaseEjectionTime().AsDuration(),
+		MaxEjectionTime:   od.GetMaxEjectionTime().AsDuration(),
+	}
+
+	if out.ConsecutiveErrors <= 0 {
+		out.ConsecutiveErrors = proxy.DefaultConsecutiveErrors
+	}
+	if out.BaseEjectionTime <= 0 {
+		out.BaseEjectionTime = proxy.DefaultBaseEjectionTime
+	}
+	if out.MaxEjectionTime <= 0 {
+		out.MaxEjectionTime = max(proxy.DefaultMaxEjectionTime, out.BaseEjectionTime)
+	}
+
+	return out
+}
+
+// compileCircuitBreaker applies defaults to a CircuitBreaker. It returns nil
+// if circuit breaking is not configured. A new CircuitBreaker starts out closed.
+func compileCircuitBreaker(cb *backendv1.CircuitBreaker) *proxy.CircuitBreaker {
+	if cb == nil {
+		return nil
+	}
+
+	out := &proxy.CircuitBreaker{
+		ErrorRateThreshold: int(cb.GetErrorRateThreshold()),
+		MinimumRequests:    int(cb.GetMinimumRequests()),
+		Window:             cb.GetWindow().AsDuration(),
+		OpenDuration:       cb.GetOpenDuration().AsDuration(),
+	}
+
+	if out.ErrorRateThreshold <= 0 {
+		out.ErrorRateThreshold = proxy.DefaultErrorRateThreshold
+	}
+	if out.MinimumRequests <= 0 {
+		out.MinimumRequests = proxy.DefaultMinimumRequests
+	}
+	if out.Window <= 0 {
+		out.Window = proxy.DefaultCircuitWindow
+	}
+	if out.OpenDuration <= 0 {
+		out.OpenDuration = proxy.DefaultCircuitOpenDuration
+	}
+
+	return out
+}
+
+// compileDecoration resolves the decoration of a backend to the values of
+// its labels. It returns nil if there is nothing to set.
+func compileDecoration(be *backendv1.Backend) *proxy.DecorationRuntime {
+	resolve := func(refs map[string]string) map[string]string {
+		var out map[string]string
+		for name, label := range refs {
+			if v, ok := be.GetMeta().GetLabels()[label]; ok {
+				if out == nil {
+					out = map[string]string{}
+				}
+				out[name] = v
+			}
+		}
+		return out
+	}
+
+	d := &proxy.DecorationRuntime{
+		Labels:       resolve(be.GetConfig().GetDecoration().GetLabels()),
+		Annotations:  resolve(be.GetConfig().GetDecoration().GetAnnotations()),
+		MaxBodyBytes: int64(min(be.GetConfig().GetDecoration().GetMaxBodyBytes(), math.MaxInt64)),
+	}
+	if d.Labels == nil && d.Annotations == nil {
+		return nil
+	}
+	return d
+}
+
+var flowDistinguishers = map[backendv1.FlowDistinguisher]proxy.FlowDistinguisher{
+	backendv1.FlowDistinguisher_FLOW_DISTINGUISHER_UNSPECIFIED: proxy.FlowDistinguisherUser,
+	backendv1.FlowDistinguisher_FLOW_DISTINGUISHER_USER:        proxy.FlowDistinguisherUser,
+	backendv1.FlowDistinguisher_FLOW_DISTINGUISHER_NAMESPACE:   proxy.FlowDistinguisherNamespace,
+}
+
+// compileFlowControl compiles the flow control of a backend. The seats of
+// max_in_flight are divided between the priority levels that are not exempt
+// in proportion to their shares, rounded up. A catch-all level is added
+// unless a level already matches every request. It returns nil if fc is
+// unset.
+func compileFlowControl(backend string, fc *backendv1.FlowControl) (*proxy.FlowControl, error) {
+	if fc == nil {
+		return nil, nil
+	}
+	if fc.GetMaxInFlight() == 0 {
+		return nil, fmt.Errorf("max_in_flight must be greater than zero")
+	}
+
+	out := &proxy.FlowControl{
+		Backend:        backend,
+		MaxLongRunning: int(fc.GetMaxLongRunning()),
+	}
+
+	seen := map[string]bool{}
+	shares := map[*proxy.PriorityLevel]int{}
+	catchAll := false
+	for i, pl := range fc.GetPriorityLevels() {
+		name := pl.GetName()
+		if name == "" || seen[name] {
+			return nil, fmt.Errorf("priority_levels[%d]: name %q is empty or not unique", i, name)
+		}
+		seen[name] = true
+
+		distinguisher, ok := flowDistinguishers[pl.GetDistinguisher()]
+		if !ok {
+			return nil, fmt.Errorf("priority_levels[%d]: unknown distinguisher %s", i, pl.GetDistinguisher())
+		}
+
+		l := &proxy.PriorityLevel{
+			Name:             name,
+			Users:            compileOptionalSet(pl.GetUsers()),
+			Groups:           compileOptionalSet(pl.GetGroups()),
+			Routes:           compileOptionalSet(pl.GetRoutes()),
+			Exempt:           pl.GetExempt(),
+			Distinguisher:    distinguisher,
+			QueueLengthLimit: int(pl.GetQueueLengthLimit()),
+			QueueTimeout:     pl.GetQueueTimeout().AsDuration(),
+		}
+		out.Levels = append(out.Levels, l)
+		shares[l] = max(1, int(pl.GetShares()))
+		catchAll = catchAll || (l.Users == nil && l.Groups == nil && l.Routes == nil)
+	}
+
+	if !catchAll {
+		if seen[proxy.CatchAllPriorityLevel] {
+			return nil, fmt.Errorf("priority level %q must match every request", proxy.CatchAllPriorityLevel)
+		}
+		l := &proxy.PriorityLevel{Name: proxy.CatchAllPriorityLevel}
+		out.Levels = append(out.Levels, l)
+		shares[l] = 1
+	}
+
+	total := 0
+	for _, l := range out.Levels {
+		if !l.Exempt {
+			total += shares[l]
+		}
+	}
+	for _, l := range out.Levels {
+		if !l.Exempt {
+			l.Seats = (int(fc.GetMaxInFlight())*shares[l] + total - 1) / total
+		}
+		if l.QueueLengthLimit <= 0 {
+			l.QueueLengthLimit = proxy.DefaultQueueLengthLimit
+		}
+		if l.QueueTimeout <= 0 {
+			l.QueueTimeout = proxy.DefaultQueueTimeout
+		}
+	}
+
+	return out, nil
+}
+
+// compileOptionalSet returns a set of values, or nil if there are none.
+func compileOptionalSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return compileSet(values, func(v string) string { return v })
+}
+
+// compileTargets builds a BackendTarget for every server of a Backend. The
+// server field is treated as a target with weight 1. Targets start out healthy
+// unless the backend is health checked and its status says otherwise.
+func compileTargets(be *backendv1.Backend, br *proxy.BackendRuntime) ([]*proxy.BackendTarget, error) {
+	unhealthy := make(map[string]bool)
+	if br.HealthCheck != nil {
+		for _, ts := range be.GetStatus().GetTargets() {
+			unhealthy[ts.GetServer()] = !ts.GetHealthy()
+		}
+	}
+
+	servers := be.GetConfig().GetTargets()
+	if s := be.GetConfig().GetServer(); s != "" {
+		servers = append([]*backendv1.BackendTarget{{Server: s, Weight: 1}}, servers...)
+	}
+
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no server or targets configured")
+	}
+
+	out := make([]*proxy.BackendTarget, 0, len(servers))
+	for _, srv := range servers {
+		serverURL, err := url.Parse(srv.GetServer())
+		if err != nil {
+			return nil, fmt.Errorf("parsing server URL %q: %w", srv.GetServer(), err)
+		}
+
+		t := &proxy.BackendTarget{
+			ID:      serverURL.String(),
+			URL:     serverURL,
+			Weight:  int(srv.GetWeight()),
+			Backend: br,
+		}
+		t.Healthy.Store(!unhealthy[t.ID])
+		out = append(out, t)
+	}
+
+	return out, nil
+}
+
 // compileRoutes2 classifies each route into the correct CompiledRoutes bucket
 // and builds an http.Handler for it.
 func compileRoutes2(
@@ -232,16 +742,14 @@ func compileRoutes2(
 	backends map[string]*proxy.BackendRuntime,
 	forwarders map[string]*proxy.Forwarder,
 ) (proxy.CompiledRoutes, error) {
-	cr := proxy.CompiledRoutes{
-		SNIExact: make(map[string][]*proxy.RouteRuntime),
-	}
+	var cr proxy.CompiledRoutes
+	backendLimiters := map[backendLimiterKey]*proxy.RateLimiter{}
 
 	for name, route := range routes {
 		ref := route.GetConfig().GetBackendRef()
 
 		br, ok := backends[ref]
 		if !ok {
-			// Backend missing or unhealthy, skip silently.
 			continue
 		}
 
@@ -250,45 +758,68 @@ func compileRoutes2(
 			continue
 		}
 
-		// Build a single-target BackendPool so the Forwarder can pick a target.
 		pool := backendPoolFromRuntime(br)
-		handler := fwd.Handler(pool)
+		retry := compileRetryPolicy(route.GetConfig().GetRetryPolicy())
 
-		rr := &proxy.RouteRuntime{
-			Name:        name,
-			BackendPool: pool,
-			Handler:     handler,
+		rewrite, err := compileRewrite(route.GetConfig().GetRewrite(), route.GetConfig().GetMatch())
+		if err != nil {
+			return proxy.CompiledRoutes{}, fmt.Errorf("route %q: %w", name, err)
 		}
 
-		match := route.GetConfig().GetMatch()
+		reqHeaders, err := compileHeaderMutation(route.GetConfig().GetRequestHeaders())
+		if err != nil {
+			return proxy.CompiledRoutes{}, fmt.Errorf("route %q: request_headers: %w", name, err)
+		}
+		respHeaders, err := compileHeaderMutation(route.GetConfig().GetResponseHeaders())
+		if err != nil {
+			return proxy.CompiledRoutes{}, fmt.Errorf("route %q: response_headers: %w", name, err)
+		}
 
-		switch {
-		case match.GetHeader().GetName() != "":
-			hm := match.GetHeader()
-			rr.Kind = proxy.RouteMatchKindHeader
-			rr.Header = &proxy.HeaderRuntime{
-				Name:      hm.GetName(),
-				Canonical: textproto.CanonicalMIMEHeaderKey(hm.GetName()),
-				Value:     hm.GetValue(),
-			}
-			cr.Headers = append(cr.Headers, rr)
+		rateLimits, err := compileRateLimits(route.GetConfig().GetRateLimits(), ref, backendLimiters)
+		if err != nil {
+			return proxy.CompiledRoutes{}, fmt.Errorf("route %q: %w", name, err)
+		}
 
-		case match.GetPath() != "":
-			rr.Kind = proxy.RouteMatchKindPath
-			rr.Path = match.GetPath()
-			cr.Paths = append(cr.Paths, rr)
+		idleTimeout := route.GetConfig().GetIdleTimeout().AsDuration()
+		if idleTimeout <= 0 {
+			idleTimeout = proxy.DefaultStreamIdleTimeout
+		}
 
-		case match.GetPathPrefix() != "":
-			rr.Kind = proxy.RouteMatchKindPathPrefix
-			rr.PathPrefix = match.GetPathPrefix()
-			cr.PathPrefixes = append(cr.PathPrefixes, rr)
+		opts := []proxy.HandlerOption{
+			proxy.WithRetryPolicy(retry),
+			proxy.WithStreamIdleTimeout(idleTimeout),
+			proxy.WithRewrite(rewrite),
+			proxy.WithHeaderMutations(reqHeaders, respHeaders),
+		}
+		handler := fwd.Handler(pool, opts...)
 
-		case match.GetSni() != "":
-			rr.Kind = proxy.RouteMatchKindSNI
-			rr.SNI = match.GetSni()
-			cr.SNIExact[rr.SNI] = append(cr.SNIExact[rr.SNI], rr)
+		fanOut := compileFanOut(route.GetConfig().GetFanOut(), backends, forwarders, opts)
+		if fanOut != nil {
+			handler = proxy.FanOutHandler(fanOut, handler)
+		}
 
-		default:
+		rr := &proxy.RouteRuntime{
+			Name:        name,
+			Timeout:     route.GetConfig().GetTimeout().AsDuration(),
+			BackendPool: pool,
+			Handler:     handler,
+			Retry:       retry,
+
+			IdleTimeout:         idleTimeout,
+			MaxRequestBodyBytes: int64(min(route.GetConfig().GetMaxRequestBodyBytes(), math.MaxInt64)),
+			Rewrite:             rewrite,
+			RequestHeaders:      reqHeaders,
+			ResponseHeaders:     respHeaders,
+			RateLimits:          rateLimits,
+			FanOut:              fanOut,
+		}
+
+		rr.Priority = route.GetConfig().GetPriority()
+		if err := compileMatch(rr, route.GetConfig().GetMatch()); err != nil {
+			return proxy.CompiledRoutes{}, fmt.Errorf("route %q: %w", name, err)
+		}
+
+		if rr.Conditions() == 0 {
 			if cr.Default != nil {
 				return proxy.CompiledRoutes{}, fmt.Errorf(
 					"route %q: multiple default routes not allowed (conflicts with %q)",
@@ -296,32 +827,537 @@ func compileRoutes2(
 				)
 			}
 			cr.Default = rr
+			continue
 		}
+		cr.Ordered = append(cr.Ordered, rr)
 	}
 
-	// Sort path-prefix routes by descending length so the most specific prefix wins.
-	sort.Slice(cr.PathPrefixes, func(i, j int) bool {
-		return len(cr.PathPrefixes[i].PathPrefix) > len(cr.PathPrefixes[j].PathPrefix)
+	sort.Slice(cr.Ordered, func(i, j int) bool {
+		if c := compareRoutes(cr.Ordered[i], cr.Ordered[j]); c != 0 {
+			return c < 0
+		}
+		return cr.Ordered[i].Name < cr.Ordered[j].Name
 	})
 
+	// Routes that compare equal are only told apart by name. Reject them if a
+	// request could match both.
+	for i := 0; i < len(cr.Ordered); i++ {
+		for j := i + 1; j < len(cr.Ordered) && compareRoutes(cr.Ordered[i], cr.Ordered[j]) == 0; j++ {
+			if overlaps(cr.Ordered[i], cr.Ordered[j]) {
+				return proxy.CompiledRoutes{}, fmt.Errorf(
+					"routes %q and %q are ambiguous: they have the same priority and may match the same requests",
+					cr.Ordered[i].Name, cr.Ordered[j].Name,
+				)
+			}
+		}
+	}
+
 	return cr, nil
 }
 
-// backendPoolFromRuntime wraps a BackendRuntime in a single-target BackendPool
-// compatible with the existing Forwarder.Handler signature.
+// compileMatch sets the match conditions of rr from m. Kind is set to the
+// most specific condition.
+func compileMatch(rr *proxy.RouteRuntime, m *routev1.Match) error {
+	if m.GetSni() != "" {
+		rr.Kind = proxy.RouteMatchKindSNI
+		rr.SNI = strings.ToLower(m.GetSni())
+	}
+	if m.GetJwt().GetClaim() != "" {
+		rr.Kind = proxy.RouteMatchKindJWT
+		rr.JWT = &proxy.JWTRuntime{
+			Claim: m.GetJwt().GetClaim(),
+			Value: m.GetJwt().GetValue(),
+		}
+	}
+	if len(m.GetMethods()) > 0 {
+		rr.Kind = proxy.RouteMatchKindMethod
+		rr.Methods = compileSet(m.GetMethods(), strings.ToUpper)
+	}
+	if len(m.GetVerbs()) > 0 {
+		rr.Kind = proxy.RouteMatchKindVerb
+		rr.Verbs = compileSet(m.GetVerbs(), strings.ToLower)
+	}
+	if len(m.GetApiGroups()) > 0 {
+		rr.Kind = proxy.RouteMatchKindAPIGroup
+		rr.APIGroups = compileSet(m.GetApiGroups(), strings.ToLower)
+	}
+	if len(m.GetResources()) > 0 {
+		rr.Kind = proxy.RouteMatchKindResource
+		rr.Resources = compileSet(m.GetResources(), strings.ToLower)
+	}
+	if ns := m.GetNamespace(); ns != nil {
+		rr.Kind = proxy.RouteMatchKindNamespace
+		rr.Namespace = &proxy.NamespaceRuntime{
+			Exact:  ns.GetExact(),
+			Prefix: ns.GetPrefix(),
+		}
+		if ns.GetRegex() != "" {
+			re, err := regexp.Compile(ns.GetRegex())
+			if err != nil {
+				return fmt.Errorf("namespace regex: %w", err)
+			}
+			rr.Namespace.Regex = re
+		}
+	}
+	for _, qm := range m.GetQueryParams() {
+		rr.Kind = proxy.RouteMatchKindQueryParam
+		qp := &proxy.QueryParamRuntime{
+			Name:    qm.GetName(),
+			Value:   qm.GetValue(),
+			Present: qm.GetPresent(),
+			Absent:  qm.GetAbsent(),
+		}
+		if qm.GetRegex() != "" {
+			re, err := regexp.Compile(qm.GetRegex())
+			if err != nil {
+				return fmt.Errorf("query parameter %q: %w", qm.GetName(), err)
+			}
+			qp.Regex = re
+		}
+		rr.QueryParams = append(rr.QueryParams, qp)
+	}
+	if m.GetHeader().GetName() != "" {
+		h, err := compileHeaderMatch(m.GetHeader())
+		if err != nil {
+			return err
+		}
+		rr.Kind = proxy.RouteMatchKindHeader
+		rr.Header = h
+	}
+	for _, hm := range m.GetHeaders() {
+		h, err := compileHeaderMatch(hm)
+		if err != nil {
+			return err
+		}
+		rr.Kind = proxy.RouteMatchKindHeader
+		rr.Headers = append(rr.Headers, h)
+	}
+	if m.GetPathPrefix() != "" {
+		rr.Kind = proxy.RouteMatchKindPathPrefix
+		rr.PathPrefix = m.GetPathPrefix()
+	}
+	if m.GetPathRegex() != "" {
+		re, err := regexp.Compile(m.GetPathRegex())
+		if err != nil {
+			return fmt.Errorf("path regex: %w", err)
+		}
+		rr.Kind = proxy.RouteMatchKindPathRegex
+		rr.PathRegex = re
+	}
+	if m.GetPath() != "" {
+		rr.Kind = proxy.RouteMatchKindPath
+		rr.Path = m.GetPath()
+	}
+	return nil
+}
+
+// compileSet returns a set of values, each passed through normalize.
+func compileSet(values []string, normalize func(string) string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[normalize(v)] = struct{}{}
+	}
+	return set
+}
+
+func compileHeaderMatch(hm *routev1.HeaderMatch) (*proxy.HeaderRuntime, error) {
+	h := &proxy.HeaderRuntime{
+		Name:      hm.GetName(),
+		Canonical: textproto.CanonicalMIMEHeaderKey(hm.GetName()),
+		Value:     hm.GetValue(),
+		Present:   hm.GetPresent(),
+		Absent:    hm.GetAbsent(),
+	}
+	if hm.GetRegex() != "" {
+		re, err := regexp.Compile(hm.GetRegex())
+		if err != nil {
+			return nil, fmt.Errorf("header %q: %w", hm.GetName(), err)
+		}
+		h.Regex = re
+	}
+	return h, nil
+}
+
+// compareRoutes orders routes by precedence. It returns a negative number if a
+// takes precedence over b, a positive number if b takes precedence over a and
+// zero if they can only be told apart by name. Precedence is decided by, in
+// order: priority, number of conditions, exact path before path regex before
+// path prefix, longest path prefix, header, query parameter and method
+// conditions, exact namespace before namespace regex before longest namespace
+// prefix, resource, api group, verb and jwt conditions, and an exact sni
+// before a wildcard sni.
+func compareRoutes(a, b *proxy.RouteRuntime) int {
+	if a.Priority != b.Priority {
+		return cmp.Compare(b.Priority, a.Priority)
+	}
+	if c := cmp.Compare(b.Conditions(), a.Conditions()); c != 0 {
+		return c
+	}
+	if c := compareBool(a.Path != "", b.Path != ""); c != 0 {
+		return c
+	}
+	if c := compareBool(a.PathRegex != nil, b.PathRegex != nil); c != 0 {
+		return c
+	}
+	if c := compareBool(a.PathPrefix != "", b.PathPrefix != ""); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(b.PathPrefix), len(a.PathPrefix)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(headerMatches(b)), len(headerMatches(a))); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(b.QueryParams), len(a.QueryParams)); c != 0 {
+		return c
+	}
+	if c := compareBool(a.Methods != nil, b.Methods != nil); c != 0 {
+		return c
+	}
+	if c := compareNamespaces(a.Namespace, b.Namespace); c != 0 {
+		return c
+	}
+	if c := compareBool(a.Resources != nil, b.Resources != nil); c != 0 {
+		return c
+	}
+	if c := compareBool(a.APIGroups != nil, b.APIGroups != nil); c != 0 {
+		return c
+	}
+	if c := compareBool(a.Verbs != nil, b.Verbs != nil); c != 0 {
+		return c
+	}
+	if c := compareBool(a.JWT != nil, b.JWT != nil); c != 0 {
+		return c
+	}
+	if c := compareBool(a.SNI != "", b.SNI != ""); c != 0 {
+		return c
+	}
+	if c := compareBool(!isWildcardSNI(a.SNI), !isWildcardSNI(b.SNI)); c != 0 {
+		return c
+	}
+	return 0
+}
+
+// compareBool orders true before false.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareNamespaces orders an exact namespace before a namespace regex before
+// the longest namespace prefix.
+func compareNamespaces(a, b *proxy.NamespaceRuntime) int {
+	if c := compareBool(a != nil, b != nil); c != 0 || a == nil {
+		return c
+	}
+	if c := compareBool(a.Exact != "", b.Exact != ""); c != 0 {
+		return c
+	}
+	if c := compareBool(a.Regex != nil, b.Regex != nil); c != 0 {
+		return c
+	}
+	return cmp.Compare(len(b.Prefix), len(a.Prefix))
+}
+
+func isWildcardSNI(sni string) bool {
+	return strings.HasPrefix(sni, "*.")
+}
+
+// headerMatches returns all header conditions of rr.
+func headerMatches(rr *proxy.RouteRuntime) []*proxy.HeaderRuntime {
+	if rr.Header == nil {
+		return rr.Headers
+	}
+	return append([]*proxy.HeaderRuntime{rr.Header}, rr.Headers...)
+}
+
+// overlaps returns true if a request could match both a and b. It assumes
+// that compareRoutes(a, b) == 0, so both have the same kinds of conditions and
+// equally long path prefixes. Regular expressions are assumed to overlap.
+func overlaps(a, b *proxy.RouteRuntime) bool {
+	if a.SNI != b.SNI || a.PathPrefix != b.PathPrefix {
+		return false
+	}
+	if a.Path != b.Path && !strings.ContainsAny(a.Path+b.Path, "*?[") {
+		return false
+	}
+	for _, sets := range [][2]map[string]struct{}{
+		{a.Methods, b.Methods},
+		{a.Resources, b.Resources},
+		{a.APIGroups, b.APIGroups},
+		{a.Verbs, b.Verbs},
+	} {
+		if sets[0] != nil && !intersects(sets[0], sets[1]) {
+			return false
+		}
+	}
+	if a.Namespace != nil && a.Namespace.Regex == nil &&
+		(a.Namespace.Exact != b.Namespace.Exact || a.Namespace.Prefix != b.Namespace.Prefix) {
+		return false
+	}
+	for _, ha := range headerMatches(a) {
+		for _, hb := range headerMatches(b) {
+			if ha.Canonical == hb.Canonical && excludes(ha.Value, hb.Value, ha.Absent, hb.Absent) {
+				return false
+			}
+		}
+	}
+	for _, qa := range a.QueryParams {
+		for _, qb := range b.QueryParams {
+			if qa.Name == qb.Name && excludes(qa.Value, qb.Value, qa.Absent, qb.Absent) {
+				return false
+			}
+		}
+	}
+	if a.JWT != nil && a.JWT.Claim == b.JWT.Claim && a.JWT.Value != b.JWT.Value {
+		return false
+	}
+	return true
+}
+
+func intersects(a, b map[string]struct{}) bool {
+	for m := range a {
+		if _, ok := b[m]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// excludes returns true if no value can satisfy two conditions on the same
+// header or query parameter: either both require different exact values, or
+// exactly one requires it to be absent.
+func excludes(valueA, valueB string, absentA, absentB bool) bool {
+	if absentA != absentB {
+		return true
+	}
+	return valueA != "" && valueB != "" && valueA != valueB
+}
+
+var impersonationModes = map[backendv1.ImpersonationMode]proxy.ImpersonationMode{
+	backendv1.ImpersonationMode_IMPERSONATION_MODE_UNSPECIFIED: proxy.ImpersonationNone,
+	backendv1.ImpersonationMode_IMPERSONATION_MODE_IDENTITY:    proxy.ImpersonationIdentity,
+}
+
+var retryOn = map[routev1.RetryOn]proxy.RetryOn{
+	routev1.RetryOn_RETRY_ON_CONNECT_FAILURE:     proxy.RetryOnConnectFailure,
+	routev1.RetryOn_RETRY_ON_BAD_GATEWAY:         proxy.RetryOnBadGateway,
+	routev1.RetryOn_RETRY_ON_SERVICE_UNAVAILABLE: proxy.RetryOnServiceUnavailable,
+	routev1.RetryOn_RETRY_ON_GATEWAY_TIMEOUT:     proxy.RetryOnGatewayTimeout,
+	routev1.RetryOn_RETRY_ON_TOO_MANY_REQUESTS:   proxy.RetryOnTooManyRequests,
+}
+
+// compileRetryPolicy applies defaults to a RetryPolicy. It returns nil if
+// retries are not configured.
+func compileRetryPolicy(rp *routev1.RetryPolicy) *proxy.RetryPolicy {
+	if rp == nil {
+		return nil
+	}
+
+	out := &proxy.RetryPolicy{
+		Attempts:            int(rp.GetAttempts()),
+		PerTryTimeout:       rp.GetPerTryTimeout().AsDuration(),
+		BudgetPercent:       int(rp.GetBudgetPercent()),
+		MinRetryConcurrency: int(rp.GetMinRetryConcurrency()),
+		MaxRetryAfter:       rp.GetMaxRetryAfter().AsDuration(),
+	}
+
+	for _, on := range rp.GetRetryOn() {
+		out.RetryOn |= retryOn[on]
+	}
+
+	if out.Attempts <= 0 {
+		out.Attempts = proxy.DefaultRetryAttempts
+	}
+	if out.RetryOn == 0 {
+		out.RetryOn = proxy.RetryOnAll
+	}
+	if out.BudgetPercent <= 0 {
+		out.BudgetPercent = proxy.DefaultRetryBudgetPercent
+	}
+	if out.MinRetryConcurrency <= 0 {
+		out.MinRetryConcurrency = proxy.DefaultMinRetryConcurrency
+	}
+	if out.MaxRetryAfter <= 0 {
+		out.MaxRetryAfter = proxy.DefaultMaxRetryAfter
+	}
+
+	return out
+}
+
+var rateLimitKeys = map[routev1.RateLimitKey]proxy.RateLimitKey{
+	routev1.RateLimitKey_RATE_LIMIT_KEY_UNSPECIFIED: proxy.RateLimitByRoute,
+	routev1.RateLimitKey_RATE_LIMIT_KEY_ROUTE:       proxy.RateLimitByRoute,
+	routev1.RateLimitKey_RATE_LIMIT_KEY_USER:        proxy.RateLimitByUser,
+	routev1.RateLimitKey_RATE_LIMIT_KEY_GROUP:       proxy.RateLimitByGroup,
+	routev1.RateLimitKey_RATE_LIMIT_KEY_CLIENT_IP:   proxy.RateLimitByClientIP,
+	routev1.RateLimitKey_RATE_LIMIT_KEY_BACKEND:     proxy.RateLimitByBackend,
+}
+
+// backendLimiterKey identifies the limiters shared by all routes to a backend.
+type backendLimiterKey struct {
+	backend string
+	rate    float64
+	burst   int
+}
+
+// compileRateLimits compiles the rate limits of a route to backend. Limits
+// keyed by backend are shared by all routes to the backend with the same rate
+// and burst. Returns nil if there are no limits.
+func compileRateLimits(limits []*routev1.RateLimit, backend string, shared map[backendLimiterKey]*proxy.RateLimiter) ([]*proxy.RateLimiter, error) {
+	var out []*proxy.RateLimiter
+	for i, rl := range limits {
+		rate := rl.GetRequestsPerSecond()
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return nil, fmt.Errorf("rate_limits[%d]: requests_per_second must be greater than zero", i)
+		}
+		burst := int(rl.GetBurst())
+		if burst <= 0 {
+			burst = int(math.Ceil(rate))
+		}
+
+		key, ok := rateLimitKeys[rl.GetKey()]
+		if !ok {
+			return nil, fmt.Errorf("rate_limits[%d]: unknown key %s", i, rl.GetKey())
+		}
+
+		if key == proxy.RateLimitByBackend {
+			k := backendLimiterKey{backend: backend, rate: rate, burst: burst}
+			if shared[k] == nil {
+				shared[k] = proxy.NewRateLimiter(key, rate, burst)
+			}
+			out = append(out, shared[k])
+			continue
+		}
+		out = append(out, proxy.NewRateLimiter(key, rate, burst))
+	}
+	return out, nil
+}
+
+// compileFanOut builds a handler for every backend of fo with opts. Backends
+// that are missing are left out, like the backends of routes. It returns nil
+// if fo is unset or none of its backends exist.
+func compileFanOut(
+	fo *routev1.FanOut,
+	backends map[string]*proxy.BackendRuntime,
+	forwarders map[string]*proxy.Forwarder,
+	opts []proxy.HandlerOption,
+) *proxy.FanOutRuntime {
+	if fo == nil {
+		return nil
+	}
+
+	out := &proxy.FanOutRuntime{
+		ClusterAnnotation: fo.GetClusterAnnotation(),
+		MaxListBytes:      int64(min(fo.GetMaxListBytes(), math.MaxInt64)),
+	}
+	if out.ClusterAnnotation == "" {
+		out.ClusterAnnotation = proxy.DefaultClusterAnnotation
+	}
+	for _, ref := range fo.GetBackendRefs() {
+		br, ok := backends[ref]
+		if !ok {
+			continue
+		}
+		fwd, ok := forwarders[ref]
+		if !ok {
+			continue
+		}
+		out.Backends = append(out.Backends, &proxy.FanOutBackend{
+			Name:    ref,
+			Handler: fwd.Handler(backendPoolFromRuntime(br), opts...),
+			Backend: br,
+		})
+	}
+
+	if len(out.Backends) == 0 {
+		return nil
+	}
+	return out
+}
+
+// compileRewrite compiles the path rewrite of a route. Prefix rewrites
+// require the route to match on a path prefix. Returns nil if rw is unset.
+func compileRewrite(rw *routev1.Rewrite, match *routev1.Match) (*proxy.RewriteRuntime, error) {
+	switch {
+	case rw.GetRegex() != nil:
+		re, err := regexp.Compile(rw.GetRegex().GetPattern())
+		if err != nil {
+			return nil, fmt.Errorf("rewrite regex: %w", err)
+		}
+		return &proxy.RewriteRuntime{
+			Regex:        re,
+			Substitution: rw.GetRegex().GetSubstitution(),
+		}, nil
+
+	case rw.GetStripPrefix() || rw.GetReplacePrefix() != "":
+		if match.GetPathPrefix() == "" {
+			return nil, fmt.Errorf("rewrite of prefix requires a path_prefix match")
+		}
+		return &proxy.RewriteRuntime{
+			Prefix:      match.GetPathPrefix(),
+			Replacement: rw.GetReplacePrefix(),
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// compileHeaderMutation parses the header value templates of hm. Returns nil
+// if hm is unset.
+func compileHeaderMutation(hm *metav1.HeaderMutation) (*proxy.HeaderMutationRuntime, error) {
+	if hm == nil {
+		return nil, nil
+	}
+
+	out := &proxy.HeaderMutationRuntime{}
+	for _, name := range hm.GetRemove() {
+		out.Remove = append(out.Remove, textproto.CanonicalMIMEHeaderKey(name))
+	}
+	for _, hv := range hm.GetSet() {
+		v, err := proxy.NewHeaderValue(hv.GetName(), hv.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("header %q: %w", hv.GetName(), err)
+		}
+		out.Set = append(out.Set, v)
+	}
+	for _, hv := range hm.GetAdd() {
+		v, err := proxy.NewHeaderValue(hv.GetName(), hv.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("header %q: %w", hv.GetName(), err)
+		}
+		out.Add = append(out.Add, v)
+	}
+
+	return out, nil
+}
+
+// backendPoolFromRuntime builds a BackendPool over all targets of a
+// BackendRuntime. Each route gets its own pool so that load balancing state
+// is not shared between routes.
 func backendPoolFromRuntime(br *proxy.BackendRuntime) *proxy.BackendPool {
 	return &proxy.BackendPool{
 		Name:    br.Name,
-		Targets: []*proxy.BackendRuntime{br},
+		Targets: br.Targets,
+		Backend: br,
 	}
 }
 
 // buildTLSTransport constructs an *http.Transport using the supplied tls.Config.
-func buildTLSTransport(cfg *tls.Config) http.RoundTripper {
+// Idle connections are kept for up to maxInFlight requests per host so that
+// the connections of a backend with flow control are reused.
+func buildTLSTransport(cfg *tls.Config, maxInFlight int) http.RoundTripper {
 	return &http.Transport{
 		TLSClientConfig:     cfg,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
+		MaxIdleConns:        max(100, maxInFlight),
+		MaxIdleConnsPerHost: max(10, maxInFlight),
 		IdleConnTimeout:     90 * time.Second,
 	}
 }
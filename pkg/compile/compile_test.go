@@ -8,16 +8,22 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	backendv1 "github.com/amimof/multikube/api/backend/v1"
 	cav1 "github.com/amimof/multikube/api/ca/v1"
 	certificatev1 "github.com/amimof/multikube/api/certificate/v1"
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
 	metav1 "github.com/amimof/multikube/api/meta/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
 	proxy "github.com/amimof/multikube/pkg/proxyv2"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // ---------------------------------------------------------------------------
@@ -88,6 +94,20 @@ func newCertificate(name, certPEM, keyPEM string) *certificatev1.Certificate {
 	}
 }
 
+func newCredential(name string, cfg *credentialv1.CredentialConfig) *credentialv1.Credential {
+	return &credentialv1.Credential{
+		Meta:   &metav1.Meta{Name: name},
+		Config: cfg,
+	}
+}
+
+func newPolicy(name string, effect policyv1.Effect, expression string) *policyv1.Policy {
+	return &policyv1.Policy{
+		Meta:   &metav1.Meta{Name: name},
+		Config: &policyv1.PolicyConfig{Name: name, Effect: effect, Expression: expression},
+	}
+}
+
 func newCAFromRef(name, certRef string) *cav1.CertificateAuthority {
 	return &cav1.CertificateAuthority{
 		Meta: &metav1.Meta{Name: name},
@@ -209,10 +229,10 @@ func TestCompile_HeaderRoute(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(rc.Routes.Headers) != 1 {
-		t.Fatalf("expected 1 header route, got %d", len(rc.Routes.Headers))
+	if len(rc.Routes.Ordered) != 1 {
+		t.Fatalf("expected 1 header route, got %d", len(rc.Routes.Ordered))
 	}
-	rr := rc.Routes.Headers[0]
+	rr := rc.Routes.Ordered[0]
 	if rr.Kind != proxy.RouteMatchKindHeader {
 		t.Errorf("expected kind Header, got %v", rr.Kind)
 	}
@@ -241,10 +261,10 @@ func TestCompile_PathRoute(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(rc.Routes.Paths) != 1 {
-		t.Fatalf("expected 1 path route, got %d", len(rc.Routes.Paths))
+	if len(rc.Routes.Ordered) != 1 {
+		t.Fatalf("expected 1 path route, got %d", len(rc.Routes.Ordered))
 	}
-	rr := rc.Routes.Paths[0]
+	rr := rc.Routes.Ordered[0]
 	if rr.Kind != proxy.RouteMatchKindPath {
 		t.Errorf("expected kind Path, got %v", rr.Kind)
 	}
@@ -274,12 +294,12 @@ func TestCompile_PathPrefixRoute_SortedLongestFirst(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(rc.Routes.PathPrefixes) != 2 {
-		t.Fatalf("expected 2 path-prefix routes, got %d", len(rc.Routes.PathPrefixes))
+	if len(rc.Routes.Ordered) != 2 {
+		t.Fatalf("expected 2 path-prefix routes, got %d", len(rc.Routes.Ordered))
 	}
 	// Longest prefix must come first.
-	if rc.Routes.PathPrefixes[0].PathPrefix != "/api/v2" {
-		t.Errorf("expected longest prefix first, got %q", rc.Routes.PathPrefixes[0].PathPrefix)
+	if rc.Routes.Ordered[0].PathPrefix != "/api/v2" {
+		t.Errorf("expected longest prefix first, got %q", rc.Routes.Ordered[0].PathPrefix)
 	}
 }
 
@@ -303,9 +323,9 @@ func TestCompile_SNIRoute(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	routes, ok := rc.Routes.SNIExact["myservice.example.com"]
-	if !ok || len(routes) != 1 {
-		t.Fatalf("expected 1 SNI route for host, got %v", rc.Routes.SNIExact)
+	routes := rc.Routes.Ordered
+	if len(routes) != 1 || routes[0].SNI != "myservice.example.com" {
+		t.Fatalf("expected 1 SNI route for host, got %v", routes)
 	}
 	if routes[0].Kind != proxy.RouteMatchKindSNI {
 		t.Errorf("expected kind SNI, got %v", routes[0].Kind)
@@ -392,8 +412,540 @@ func TestCompile_BackendPool_SingleTarget(t *testing.T) {
 	if len(pool.Targets) != 1 {
 		t.Fatalf("expected 1 target in pool, got %d", len(pool.Targets))
 	}
-	if pool.Targets[0].Name != "be" {
-		t.Errorf("expected target name %q, got %q", "be", pool.Targets[0].Name)
+	if pool.Targets[0].Backend.Name != "be" {
+		t.Errorf("expected target backend name %q, got %q", "be", pool.Targets[0].Backend.Name)
+	}
+}
+
+func TestCompile_BackendPool_MultipleTargets(t *testing.T) {
+	c := NewCompiler()
+	be := newBackend("be", "https://10.0.0.1:6443")
+	be.Config.Targets = []*backendv1.BackendTarget{
+		{Server: "https://10.0.0.2:6443", Weight: 3},
+		{Server: "https://10.0.0.3:6443"},
+	}
+	st := &State{
+		Backends: map[string]*backendv1.Backend{
+			"be": be,
+		},
+		Routes: map[string]*routev1.Route{
+			"r": newRoute("r", "be", nil),
+		},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pool := rc.Routes.Default.BackendPool
+	if len(pool.Targets) != 3 {
+		t.Fatalf("expected 3 targets in pool, got %d", len(pool.Targets))
+	}
+
+	wantHosts := []string{"10.0.0.1:6443", "10.0.0.2:6443", "10.0.0.3:6443"}
+	wantWeights := []int{1, 3, 0}
+	for i, target := range pool.Targets {
+		if target.URL.Host != wantHosts[i] {
+			t.Errorf("target %d: expected host %q, got %q", i, wantHosts[i], target.URL.Host)
+		}
+		if target.Weight != wantWeights[i] {
+			t.Errorf("target %d: expected weight %d, got %d", i, wantWeights[i], target.Weight)
+		}
+		if !target.Healthy.Load() {
+			t.Errorf("target %d: expected target to start out healthy", i)
+		}
+	}
+}
+
+func TestCompile_Backend_NoServer_Error(t *testing.T) {
+	c := NewCompiler()
+	st := &State{
+		Backends: map[string]*backendv1.Backend{
+			"be": newBackend("be", ""),
+		},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	_, err := c.Compile(st)
+	if err == nil {
+		t.Fatal("expected error for backend without server or targets, got nil")
+	}
+}
+
+func TestCompile_HealthCheck_Defaults(t *testing.T) {
+	c := NewCompiler()
+	be := newBackend("be", "https://10.0.0.1:6443")
+	be.Config.HealthCheck = &backendv1.HealthCheck{UnhealthyThreshold: 5}
+	be.Status = &backendv1.BackendStatus{
+		Targets: []*backendv1.TargetStatus{{Server: "https://10.0.0.1:6443", Healthy: false}},
+	}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": be},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	br := rc.Backends["be"]
+	want := proxy.HealthCheckRuntime{
+		Path:               proxy.DefaultHealthCheckPath,
+		Interval:           proxy.DefaultHealthCheckInterval,
+		Timeout:            proxy.DefaultHealthCheckTimeout,
+		HealthyThreshold:   proxy.DefaultHealthyThreshold,
+		UnhealthyThreshold: 5,
+	}
+	if br.HealthCheck == nil || *br.HealthCheck != want {
+		t.Fatalf("expected health check %+v, got %+v", want, br.HealthCheck)
+	}
+	if br.Targets[0].Healthy.Load() {
+		t.Error("expected target reported unhealthy in status to start out unhealthy")
+	}
+}
+
+func TestCompile_CacheTTL(t *testing.T) {
+	c := NewCompiler()
+	cached := newBackend("cached", "https://10.0.0.1:6443")
+	cached.Config.CacheTtl = durationpb.New(30 * time.Second)
+	cached.Config.CacheMaxBytes = 16
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"cached": cached, "uncached": newBackend("uncached", "https://10.0.0.2:6443")},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if br := rc.Backends["cached"]; br.Cache == nil || br.Cache.TTL != 30*time.Second {
+		t.Errorf("expected response cache with TTL 30s, got %+v", br.Cache)
+	}
+	if br := rc.Backends["cached"]; br.Cache != nil {
+		br.Cache.Set("small", make([]byte, 16))
+		br.Cache.Set("large", make([]byte, 17))
+		if !br.Cache.Exists("small") || br.Cache.Exists("large") {
+			t.Error("expected the response cache to be bounded by cache_max_bytes")
+		}
+	}
+	if br := rc.Backends["uncached"]; br.Cache != nil {
+		t.Error("expected no response cache without cache_ttl")
+	}
+}
+
+func TestCompile_Impersonation(t *testing.T) {
+	impersonating := newBackend("impersonating", "https://10.0.0.1:6443")
+	impersonating.Config.Impersonation = backendv1.ImpersonationMode_IMPERSONATION_MODE_IDENTITY
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"impersonating": impersonating, "plain": newBackend("plain", "https://10.0.0.2:6443")},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mode := rc.Backends["impersonating"].Impersonation; mode != proxy.ImpersonationIdentity {
+		t.Errorf("expected identity impersonation, got %v", mode)
+	}
+	if mode := rc.Backends["plain"].Impersonation; mode != proxy.ImpersonationNone {
+		t.Errorf("expected no impersonation, got %v", mode)
+	}
+}
+
+func TestCompile_FlowControl(t *testing.T) {
+	be := newBackend("be", "https://10.0.0.1:6443")
+	be.Config.FlowControl = &backendv1.FlowControl{
+		MaxInFlight:    20,
+		MaxLongRunning: 50,
+		PriorityLevels: []*backendv1.PriorityLevel{
+			{Name: "exempt", Groups: []string{"system:masters"}, Exempt: true},
+			{
+				Name:             "ci",
+				Users:            []string{"ci"},
+				Shares:           3,
+				Distinguisher:    backendv1.FlowDistinguisher_FLOW_DISTINGUISHER_NAMESPACE,
+				QueueLengthLimit: 5,
+				QueueTimeout:     durationpb.New(time.Second),
+			},
+		},
+	}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": be},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fc := rc.Backends["be"].FlowControl
+	if fc == nil {
+		t.Fatal("expected flow control to be compiled")
+	}
+	if fc.Backend != "be" || fc.MaxLongRunning != 50 {
+		t.Errorf("unexpected flow control: backend=%s max-long-running=%d", fc.Backend, fc.MaxLongRunning)
+	}
+
+	var names []string
+	for _, l := range fc.Levels {
+		names = append(names, l.Name)
+	}
+	if got := strings.Join(names, ","); got != "exempt,ci,"+proxy.CatchAllPriorityLevel {
+		t.Fatalf("expected a catch-all level to be added, got levels %s", got)
+	}
+
+	ci, catchAll := fc.Levels[1], fc.Levels[2]
+	if ci.Seats != 15 || catchAll.Seats != 5 {
+		t.Errorf("expected seats to be divided by shares, got ci=%d catch-all=%d", ci.Seats, catchAll.Seats)
+	}
+	if ci.Distinguisher != proxy.FlowDistinguisherNamespace || ci.QueueLengthLimit != 5 || ci.QueueTimeout != time.Second {
+		t.Errorf("unexpected ci level: %+v", ci)
+	}
+	if catchAll.QueueLengthLimit != proxy.DefaultQueueLengthLimit || catchAll.QueueTimeout != proxy.DefaultQueueTimeout {
+		t.Errorf("expected defaults on the catch-all level, got queue length %d and timeout %s", catchAll.QueueLengthLimit, catchAll.QueueTimeout)
+	}
+
+	if tr := rc.Backends["be"].Transport.(*http.Transport); tr.MaxIdleConnsPerHost != 20 {
+		t.Errorf("expected 20 idle connections per host, got %d", tr.MaxIdleConnsPerHost)
+	}
+}
+
+func TestCompile_FlowControl_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		fc   *backendv1.FlowControl
+	}{
+		{name: "no max in flight", fc: &backendv1.FlowControl{}},
+		{
+			name: "duplicate level",
+			fc: &backendv1.FlowControl{MaxInFlight: 10, PriorityLevels: []*backendv1.PriorityLevel{
+				{Name: "a", Users: []string{"x"}},
+				{Name: "a", Users: []string{"y"}},
+			}},
+		},
+		{
+			name: "partial catch-all",
+			fc: &backendv1.FlowControl{MaxInFlight: 10, PriorityLevels: []*backendv1.PriorityLevel{
+				{Name: proxy.CatchAllPriorityLevel, Users: []string{"x"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be := newBackend("be", "https://10.0.0.1:6443")
+			be.Config.FlowControl = tt.fc
+			st := &State{
+				Backends:               map[string]*backendv1.Backend{"be": be},
+				Routes:                 map[string]*routev1.Route{},
+				Certificates:           map[string]*certificatev1.Certificate{},
+				CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+			}
+			if _, err := NewCompiler().Compile(st); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestCompile_Decoration(t *testing.T) {
+	decorated := newBackend("decorated", "https://10.0.0.1:6443")
+	decorated.Meta.Labels = map[string]string{"cluster": "prod-eu", "region": "eu-west-1"}
+	decorated.Config.Decoration = &backendv1.Decoration{
+		Labels:      map[string]string{"multikube.io/cluster": "cluster", "multikube.io/env": "env"},
+		Annotations: map[string]string{"multikube.io/region": "region"},
+	}
+	unlabeled := newBackend("unlabeled", "https://10.0.0.2:6443")
+	unlabeled.Config.Decoration = &backendv1.Decoration{Labels: map[string]string{"multikube.io/cluster": "cluster"}}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"decorated": decorated, "unlabeled": unlabeled},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := rc.Backends["decorated"].Decoration
+	if d == nil {
+		t.Fatal("expected decoration to be compiled")
+	}
+	if len(d.Labels) != 1 || d.Labels["multikube.io/cluster"] != "prod-eu" {
+		t.Errorf("expected labels resolved from the backend labels, got %v", d.Labels)
+	}
+	if len(d.Annotations) != 1 || d.Annotations["multikube.io/region"] != "eu-west-1" {
+		t.Errorf("expected annotations resolved from the backend labels, got %v", d.Annotations)
+	}
+	if rc.Backends["unlabeled"].Decoration != nil {
+		t.Error("expected no decoration when none of the backend labels are set")
+	}
+}
+
+func TestCompile_Policies(t *testing.T) {
+	be := newBackend("be", "https://10.0.0.1:6443")
+	be.Meta.Labels = map[string]string{"env": "prod"}
+	deny := newPolicy("deny-secrets", policyv1.Effect_EFFECT_DENY, `request.resource == "secrets"`)
+	deny.Config.Message = "secrets are off limits"
+	deny.Config.Routes = []string{"r"}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": be},
+		Routes:                 map[string]*routev1.Route{"r": newRoute("r", "be", nil)},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+		Policies: map[string]*policyv1.Policy{
+			"deny-secrets": deny,
+			"allow-prod":   newPolicy("allow-prod", policyv1.Effect_EFFECT_ALLOW, `backend.labels.env == "prod"`),
+		},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rc.Backends["be"].Labels["env"] != "prod" {
+		t.Errorf("expected the labels of the backend, got %v", rc.Backends["be"].Labels)
+	}
+	a := rc.Authorizer
+	if a == nil || len(a.Policies) != 2 {
+		t.Fatalf("expected 2 policies, got %+v", a)
+	}
+	allow, denied := a.Policies[0], a.Policies[1]
+	if allow.Name != "allow-prod" || allow.Effect != proxy.PolicyEffectAllow || allow.Routes != nil {
+		t.Errorf("unexpected policy %+v", allow)
+	}
+	if denied.Name != "deny-secrets" || denied.Effect != proxy.PolicyEffectDeny || denied.Message != "secrets are off limits" {
+		t.Errorf("unexpected policy %+v", denied)
+	}
+	if _, ok := denied.Routes["r"]; !ok || len(denied.Routes) != 1 {
+		t.Errorf("expected the policy to apply to route r, got %v", denied.Routes)
+	}
+
+	route := rc.Routes.Default
+	for target, want := range map[string]bool{"/api/v1/pods": true, "/api/v1/secrets": false} {
+		if d := a.Authorize(httptest.NewRequest(http.MethodGet, target, nil), route, time.Now()); d.Allowed != want {
+			t.Errorf("%s: expected allowed %t, got %+v", target, want, d)
+		}
+	}
+}
+
+func TestCompile_Admission(t *testing.T) {
+	guardrails := newPolicy("guardrails", policyv1.Effect_EFFECT_UNSPECIFIED, `request.resource == "pods"`)
+	guardrails.Config.Validations = []*policyv1.Validation{{Expression: `!has(object.spec.hostNetwork)`, Message: "host network is not allowed"}}
+	defaults := newPolicy("defaults", policyv1.Effect_EFFECT_ALLOW, `true`)
+	defaults.Config.Mutation = &policyv1.Mutation{Labels: map[string]string{"owner": "platform"}}
+	st := &State{
+		Policies: map[string]*policyv1.Policy{
+			"guardrails": guardrails,
+			"defaults":   defaults,
+			"allow-all":  newPolicy("allow-all", policyv1.Effect_EFFECT_ALLOW, `true`),
+		},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var authorized, admitted []string
+	for _, p := range rc.Authorizer.Policies {
+		authorized = append(authorized, p.Name)
+	}
+	for _, p := range rc.Admission.Policies {
+		admitted = append(admitted, p.Name)
+	}
+	if got := strings.Join(authorized, ","); got != "allow-all,defaults" {
+		t.Errorf("expected the policies with an effect to authorize requests, got %s", got)
+	}
+	if got := strings.Join(admitted, ","); got != "defaults,guardrails" {
+		t.Errorf("expected the policies with validations or a mutation to check objects, got %s", got)
+	}
+
+	g := rc.Admission.Policies[1]
+	if len(g.Validations) != 1 || g.Validations[0].Message != "host network is not allowed" || g.Mutation != nil {
+		t.Errorf("unexpected policy %+v", g)
+	}
+	if d := rc.Admission.Policies[0].Mutation; d == nil || d.Labels["owner"] != "platform" {
+		t.Errorf("expected a mutation setting owner, got %+v", d)
+	}
+}
+
+func TestCompile_Policies_Errors(t *testing.T) {
+	if rc, err := NewCompiler().Compile(&State{}); err != nil || rc.Authorizer != nil {
+		t.Errorf("expected no authorizer without policies, got %+v, %v", rc.Authorizer, err)
+	}
+
+	tests := []struct {
+		name   string
+		policy *policyv1.Policy
+	}{
+		{name: "invalid expression", policy: newPolicy("p", policyv1.Effect_EFFECT_ALLOW, `user.name ==`)},
+		{name: "not a bool", policy: newPolicy("p", policyv1.Effect_EFFECT_DENY, `size(user.name)`)},
+		{name: "nothing to do", policy: newPolicy("p", policyv1.Effect_EFFECT_UNSPECIFIED, `true`)},
+		{name: "invalid validation", policy: &policyv1.Policy{
+			Meta: &metav1.Meta{Name: "p"},
+			Config: &policyv1.PolicyConfig{
+				Expression:  `true`,
+				Validations: []*policyv1.Validation{{Expression: `object.spec.`}},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCompiler().Compile(&State{Policies: map[string]*policyv1.Policy{"p": tt.policy}})
+			if err == nil || !strings.Contains(err.Error(), `policy "p"`) {
+				t.Errorf("expected an error naming the policy, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCompile_RetryPolicy(t *testing.T) {
+	c := NewCompiler()
+	route := newRoute("r", "be", nil)
+	route.Config.RetryPolicy = &routev1.RetryPolicy{
+		Attempts: 3,
+		RetryOn:  []routev1.RetryOn{routev1.RetryOn_RETRY_ON_CONNECT_FAILURE, routev1.RetryOn_RETRY_ON_SERVICE_UNAVAILABLE},
+	}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": newBackend("be", "https://10.0.0.1:6443")},
+		Routes:                 map[string]*routev1.Route{"r": route},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rp := rc.Routes.Default.Retry
+	if rp == nil {
+		t.Fatal("expected retry policy to be compiled")
+	}
+	if rp.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", rp.Attempts)
+	}
+	if rp.RetryOn != proxy.RetryOnConnectFailure|proxy.RetryOnServiceUnavailable {
+		t.Errorf("unexpected retry-on set %b", rp.RetryOn)
+	}
+	if rp.BudgetPercent != proxy.DefaultRetryBudgetPercent || rp.MaxRetryAfter != proxy.DefaultMaxRetryAfter {
+		t.Errorf("expected defaults to be applied, got %+v", rp)
+	}
+}
+
+func TestCompile_RateLimits(t *testing.T) {
+	a := newRoute("a", "be", &routev1.Match{PathPrefix: "/api"})
+	a.Config.RateLimits = []*routev1.RateLimit{
+		{RequestsPerSecond: 2.5},
+		{RequestsPerSecond: 10, Burst: 20, Key: routev1.RateLimitKey_RATE_LIMIT_KEY_USER},
+		{RequestsPerSecond: 100, Key: routev1.RateLimitKey_RATE_LIMIT_KEY_BACKEND},
+	}
+	b := newRoute("b", "be", &routev1.Match{PathPrefix: "/apis"})
+	b.Config.RateLimits = []*routev1.RateLimit{
+		{RequestsPerSecond: 100, Key: routev1.RateLimitKey_RATE_LIMIT_KEY_BACKEND},
+	}
+
+	rc, err := compileRoutes(t, a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	routes := map[string]*proxy.RouteRuntime{}
+	for _, rr := range rc.Routes.Ordered {
+		routes[rr.Name] = rr
+	}
+
+	limits := routes["a"].RateLimits
+	if len(limits) != 3 {
+		t.Fatalf("expected 3 rate limits, got %d", len(limits))
+	}
+	if l := limits[0]; l.Key != proxy.RateLimitByRoute || l.Rate != 2.5 || l.Burst != 3 {
+		t.Errorf("expected a route limit of 2.5/s with burst 3, got %s %v/s with burst %d", l.Key, l.Rate, l.Burst)
+	}
+	if l := limits[1]; l.Key != proxy.RateLimitByUser || l.Rate != 10 || l.Burst != 20 {
+		t.Errorf("expected a user limit of 10/s with burst 20, got %s %v/s with burst %d", l.Key, l.Rate, l.Burst)
+	}
+	if limits[2] != routes["b"].RateLimits[0] {
+		t.Error("expected backend limits to be shared between routes to the backend")
+	}
+}
+
+func TestCompile_RateLimits_InvalidRate(t *testing.T) {
+	route := newRoute("r", "be", nil)
+	route.Config.RateLimits = []*routev1.RateLimit{{RequestsPerSecond: 0}}
+
+	if _, err := compileRoutes(t, route); err == nil {
+		t.Fatal("expected error for a rate of 0")
+	}
+}
+
+func TestCompile_FanOut(t *testing.T) {
+	all := newRoute("all", "a", &routev1.Match{PathPrefix: "/clusters/all"})
+	all.Config.FanOut = &routev1.FanOut{BackendRefs: []string{"a", "missing", "b"}}
+	gone := newRoute("gone", "a", &routev1.Match{PathPrefix: "/clusters/gone"})
+	gone.Config.FanOut = &routev1.FanOut{BackendRefs: []string{"missing"}, ClusterAnnotation: "example.com/cluster"}
+	st := &State{
+		Backends: map[string]*backendv1.Backend{
+			"a": newBackend("a", "https://10.0.0.1:6443"),
+			"b": newBackend("b", "https://10.0.0.2:6443"),
+		},
+		Routes:                 map[string]*routev1.Route{"all": all, "gone": gone},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := NewCompiler().Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	routes := map[string]*proxy.RouteRuntime{}
+	for _, rr := range rc.Routes.Ordered {
+		routes[rr.Name] = rr
+	}
+
+	fo := routes["all"].FanOut
+	if fo == nil {
+		t.Fatal("expected fan-out to be compiled")
+	}
+	var names []string
+	for _, b := range fo.Backends {
+		names = append(names, b.Name)
+		if b.Backend != rc.Backends[b.Name] {
+			t.Errorf("expected fan-out backend %s to refer to its runtime", b.Name)
+		}
+	}
+	if got := strings.Join(names, ","); got != "a,b" {
+		t.Errorf("expected fan-out to backends a,b, got %s", got)
+	}
+	if fo.ClusterAnnotation != proxy.DefaultClusterAnnotation {
+		t.Errorf("expected the default cluster annotation, got %q", fo.ClusterAnnotation)
+	}
+	if routes["gone"].FanOut != nil {
+		t.Error("expected no fan-out when none of its backends exist")
 	}
 }
 
@@ -523,3 +1075,629 @@ func TestCompile_Certificate_MissingKey_Error(t *testing.T) {
 		t.Fatal("expected error for missing key PEM, got nil")
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Tests — Credential compilation
+// ---------------------------------------------------------------------------
+
+func TestCompile_Credentials(t *testing.T) {
+	certPEM, keyPEM := selfSignedPEM(t)
+
+	tests := []struct {
+		name    string
+		authRef string
+		want    proxy.RequestAuthInjector
+		tlsCert bool
+	}{
+		{name: "token", authRef: "token", want: &proxy.BearerTokenAuth{Token: "s3cr3t"}},
+		{name: "basic", authRef: "basic", want: &proxy.BasicAuth{Username: "admin", Password: "pw"}},
+		{name: "client certificate", authRef: "cert-cred", tlsCert: true},
+		{name: "certificate ref", authRef: "mycert", tlsCert: true},
+		{name: "token file", authRef: "token-file", want: &proxy.FileTokenAuth{Path: "/var/run/secrets/token"}},
+		{name: "exec", authRef: "exec", want: &proxy.ExecAuth{
+			Command:       "aws",
+			Args:          []string{"eks", "get-token"},
+			Env:           []string{"AWS_PROFILE=prod", "AWS_REGION=eu-north-1"},
+			RefreshBefore: 5 * time.Minute,
+		}},
+		{name: "none", authRef: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be := newBackend("b1", "https://127.0.0.1:6443")
+			be.Config.AuthRef = tt.authRef
+
+			st := &State{
+				Backends: map[string]*backendv1.Backend{"b1": be},
+				Routes:   map[string]*routev1.Route{},
+				Certificates: map[string]*certificatev1.Certificate{
+					"mycert": newCertificate("mycert", certPEM, keyPEM),
+				},
+				CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+				Credentials: map[string]*credentialv1.Credential{
+					"token":      newCredential("token", &credentialv1.CredentialConfig{Token: "s3cr3t"}),
+					"basic":      newCredential("basic", &credentialv1.CredentialConfig{Basic: &credentialv1.BasicAuth{Username: "admin", Password: "pw"}}),
+					"cert-cred":  newCredential("cert-cred", &credentialv1.CredentialConfig{ClientCertificateRef: "mycert"}),
+					"token-file": newCredential("token-file", &credentialv1.CredentialConfig{TokenFile: "/var/run/secrets/token"}),
+					"exec": newCredential("exec", &credentialv1.CredentialConfig{Exec: &credentialv1.ExecConfig{
+						Command:       "aws",
+						Args:          []string{"eks", "get-token"},
+						Env:           map[string]string{"AWS_REGION": "eu-north-1", "AWS_PROFILE": "prod"},
+						RefreshBefore: durationpb.New(5 * time.Minute),
+					}}),
+				},
+			}
+
+			rc, err := NewCompiler(WithExecCommands("aws"), WithTokenFileDirs("/var/run/secrets")).Compile(st)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			br := rc.Backends["b1"]
+
+			if tt.tlsCert {
+				if _, ok := br.AuthInjector.(*proxy.ClientCertificateAuth); !ok {
+					t.Fatalf("expected ClientCertificateAuth, got %T", br.AuthInjector)
+				}
+				if len(br.TLSConfig.Certificates) != 1 {
+					t.Fatalf("expected the client certificate in the TLS config, got %d certificates", len(br.TLSConfig.Certificates))
+				}
+				return
+			}
+
+			if len(br.TLSConfig.Certificates) != 0 {
+				t.Errorf("expected no client certificate, got %d", len(br.TLSConfig.Certificates))
+			}
+			switch want := tt.want.(type) {
+			case nil:
+				if br.AuthInjector != nil {
+					t.Errorf("expected no AuthInjector, got %T", br.AuthInjector)
+				}
+			case *proxy.BearerTokenAuth:
+				got, ok := br.AuthInjector.(*proxy.BearerTokenAuth)
+				if !ok || *got != *want {
+					t.Errorf("expected %+v, got %+v", want, br.AuthInjector)
+				}
+			case *proxy.BasicAuth:
+				got, ok := br.AuthInjector.(*proxy.BasicAuth)
+				if !ok || *got != *want {
+					t.Errorf("expected %+v, got %+v", want, br.AuthInjector)
+				}
+			case *proxy.FileTokenAuth:
+				got, ok := br.AuthInjector.(*proxy.FileTokenAuth)
+				if !ok || got.Path != want.Path {
+					t.Errorf("expected token file %s, got %+v", want.Path, br.AuthInjector)
+				}
+			case *proxy.ExecAuth:
+				got, ok := br.AuthInjector.(*proxy.ExecAuth)
+				if !ok {
+					t.Fatalf("expected ExecAuth, got %T", br.AuthInjector)
+				}
+				if got.Command != want.Command || !slices.Equal(got.Args, want.Args) || !slices.Equal(got.Env, want.Env) || got.RefreshBefore != want.RefreshBefore {
+					t.Errorf("expected %+v, got %+v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCompile_Credentials_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		authRef     string
+		credentials map[string]*credentialv1.Credential
+	}{
+		{name: "missing auth ref", authRef: "does-not-exist"},
+		{
+			name:    "missing client certificate ref",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{ClientCertificateRef: "does-not-exist"}),
+			},
+		},
+		{
+			name:    "exec without command",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{Exec: &credentialv1.ExecConfig{}}),
+			},
+		},
+		{
+			name:    "exec command not allowed",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{Exec: &credentialv1.ExecConfig{Command: "sh", Args: []string{"-c", "id"}}}),
+			},
+		},
+		{
+			name:    "token file not allowed",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{TokenFile: "/etc/shadow"}),
+			},
+		},
+		{
+			name:    "token file outside allowed directory",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{TokenFile: "/var/run/secrets/../../../etc/shadow"}),
+			},
+		},
+		{
+			name:    "empty credential",
+			authRef: "cred",
+			credentials: map[string]*credentialv1.Credential{
+				"cred": newCredential("cred", &credentialv1.CredentialConfig{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be := newBackend("b1", "https://127.0.0.1:6443")
+			be.Config.AuthRef = tt.authRef
+
+			st := &State{
+				Backends:               map[string]*backendv1.Backend{"b1": be},
+				Routes:                 map[string]*routev1.Route{},
+				Certificates:           map[string]*certificatev1.Certificate{},
+				CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+				Credentials:            tt.credentials,
+			}
+
+			if _, err := NewCompiler(WithExecCommands("aws"), WithTokenFileDirs("/var/run/secrets")).Compile(st); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestCompile_RouteSettings(t *testing.T) {
+	c := NewCompiler()
+	tuned := newRoute("tuned", "be", &routev1.Match{PathPrefix: "/api"})
+	tuned.Config.Timeout = durationpb.New(2 * time.Minute)
+	tuned.Config.IdleTimeout = durationpb.New(30 * time.Minute)
+	tuned.Config.MaxRequestBodyBytes = 3 << 20
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": newBackend("be", "https://10.0.0.1:6443")},
+		Routes:                 map[string]*routev1.Route{"tuned": tuned, "plain": newRoute("plain", "be", nil)},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rr := rc.Routes.Ordered[0]
+	if rr.Timeout != 2*time.Minute || rr.IdleTimeout != 30*time.Minute || rr.MaxRequestBodyBytes != 3<<20 {
+		t.Errorf("unexpected route settings: timeout=%s idle=%s max-body=%d", rr.Timeout, rr.IdleTimeout, rr.MaxRequestBodyBytes)
+	}
+
+	def := rc.Routes.Default
+	if def.Timeout != 0 || def.IdleTimeout != proxy.DefaultStreamIdleTimeout || def.MaxRequestBodyBytes != 0 {
+		t.Errorf("expected defaults, got timeout=%s idle=%s max-body=%d", def.Timeout, def.IdleTimeout, def.MaxRequestBodyBytes)
+	}
+}
+
+func TestCompile_Rewrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   *routev1.Match
+		rewrite *routev1.Rewrite
+		want    proxy.RewriteRuntime
+		wantErr bool
+	}{
+		{
+			name:    "strip prefix",
+			match:   &routev1.Match{PathPrefix: "/clusters/prod"},
+			rewrite: &routev1.Rewrite{StripPrefix: true},
+			want:    proxy.RewriteRuntime{Prefix: "/clusters/prod"},
+		},
+		{
+			name:    "replace prefix",
+			match:   &routev1.Match{PathPrefix: "/prod"},
+			rewrite: &routev1.Rewrite{ReplacePrefix: "/k8s"},
+			want:    proxy.RewriteRuntime{Prefix: "/prod", Replacement: "/k8s"},
+		},
+		{
+			name:    "regex",
+			match:   &routev1.Match{PathPrefix: "/clusters"},
+			rewrite: &routev1.Rewrite{Regex: &routev1.RegexRewrite{Pattern: `^/clusters/[^/]+`, Substitution: "/"}},
+			want:    proxy.RewriteRuntime{Substitution: "/"},
+		},
+		{
+			name:    "prefix without path_prefix match",
+			match:   &routev1.Match{Sni: "prod.example.com"},
+			rewrite: &routev1.Rewrite{StripPrefix: true},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			match:   &routev1.Match{PathPrefix: "/clusters"},
+			rewrite: &routev1.Rewrite{Regex: &routev1.RegexRewrite{Pattern: "("}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompiler()
+			route := newRoute("r", "be", tt.match)
+			route.Config.Rewrite = tt.rewrite
+			st := &State{
+				Backends:               map[string]*backendv1.Backend{"be": newBackend("be", "https://10.0.0.1:6443")},
+				Routes:                 map[string]*routev1.Route{"r": route},
+				Certificates:           map[string]*certificatev1.Certificate{},
+				CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+			}
+
+			rc, err := c.Compile(st)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rw := rc.Routes.Ordered[0].Rewrite
+			if rw == nil {
+				t.Fatal("expected rewrite to be compiled")
+			}
+			if rw.Prefix != tt.want.Prefix || rw.Replacement != tt.want.Replacement || rw.Substitution != tt.want.Substitution {
+				t.Errorf("expected rewrite %+v, got %+v", tt.want, rw)
+			}
+			if (rw.Regex != nil) != (tt.rewrite.GetRegex() != nil) {
+				t.Errorf("expected regex to be compiled only for regex rewrites, got %v", rw.Regex)
+			}
+		})
+	}
+}
+
+func TestCompile_HeaderMutations(t *testing.T) {
+	c := NewCompiler()
+	be := newBackend("be", "https://10.0.0.1:6443")
+	be.Config.ResponseHeaders = &metav1.HeaderMutation{
+		Set: []*metav1.HeaderValue{{Name: "x-multikube-cluster", Value: "{{ .Backend }}"}},
+	}
+	route := newRoute("r", "be", nil)
+	route.Config.RequestHeaders = &metav1.HeaderMutation{
+		Remove: []string{"authorization"},
+		Add:    []*metav1.HeaderValue{{Name: "X-Remote-User", Value: "{{ .Subject }}"}},
+	}
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": be},
+		Routes:                 map[string]*routev1.Route{"r": route},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+
+	rc, err := c.Compile(st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp := rc.Backends["be"].ResponseHeaders
+	if resp == nil || len(resp.Set) != 1 || resp.Set[0].Name != "X-Multikube-Cluster" {
+		t.Errorf("expected canonical backend response header, got %+v", resp)
+	}
+	req := rc.Routes.Default.RequestHeaders
+	if req == nil || len(req.Remove) != 1 || req.Remove[0] != "Authorization" || len(req.Add) != 1 {
+		t.Errorf("unexpected route request headers %+v", req)
+	}
+
+	route.Config.RequestHeaders.Add[0].Value = "{{ .Subject"
+	if _, err := c.Compile(st); err == nil {
+		t.Error("expected invalid template to fail compilation")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — route precedence
+// ---------------------------------------------------------------------------
+
+func compileRoutes(t *testing.T, routes ...*routev1.Route) (*proxy.RuntimeConfig, error) {
+	t.Helper()
+	st := &State{
+		Backends:               map[string]*backendv1.Backend{"be": newBackend("be", "https://10.0.0.1:6443")},
+		Routes:                 map[string]*routev1.Route{},
+		Certificates:           map[string]*certificatev1.Certificate{},
+		CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+	}
+	for _, r := range routes {
+		st.Routes[r.GetMeta().GetName()] = r
+	}
+	return NewCompiler().Compile(st)
+}
+
+func withPriority(r *routev1.Route, priority int32) *routev1.Route {
+	r.Config.Priority = priority
+	return r
+}
+
+func TestCompile_CompoundRoute(t *testing.T) {
+	rc, err := compileRoutes(t, newRoute("r", "be", &routev1.Match{
+		Sni:        "prod.example.com",
+		Jwt:        &routev1.JWTMatch{Claim: "team", Value: "platform"},
+		PathPrefix: "/apis",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rr := rc.Routes.Ordered[0]
+	if rr.SNI != "prod.example.com" || rr.JWT == nil || rr.PathPrefix != "/apis" {
+		t.Fatalf("expected all conditions to be compiled, got %+v", rr)
+	}
+	if rr.Kind != proxy.RouteMatchKindPathPrefix {
+		t.Errorf("expected kind PathPrefix, got %v", rr.Kind)
+	}
+	if rr.Conditions() != 3 {
+		t.Errorf("expected 3 conditions, got %d", rr.Conditions())
+	}
+}
+
+func TestCompile_RouteOrder(t *testing.T) {
+	rc, err := compileRoutes(t,
+		newRoute("sni", "be", &routev1.Match{Sni: "a.example.com"}),
+		newRoute("header", "be", &routev1.Match{Header: &routev1.HeaderMatch{Name: "X-Tenant", Value: "acme"}}),
+		newRoute("prefix", "be", &routev1.Match{PathPrefix: "/api"}),
+		newRoute("compound", "be", &routev1.Match{Sni: "a.example.com", PathPrefix: "/api"}),
+		withPriority(newRoute("important", "be", &routev1.Match{Sni: "b.example.com"}), 10),
+		newRoute("other-sni", "be", &routev1.Match{Sni: "c.example.com"}),
+		newRoute("wildcard-sni", "be", &routev1.Match{Sni: "*.example.com"}),
+		newRoute("regex", "be", &routev1.Match{PathRegex: "^/apis/"}),
+		newRoute("default", "be", nil),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, rr := range rc.Routes.Ordered {
+		got = append(got, rr.Name)
+	}
+	want := []string{"important", "compound", "regex", "prefix", "header", "other-sni", "sni", "wildcard-sni"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected order %v, got %v", want, got)
+	}
+	if rc.Routes.Default.Name != "default" {
+		t.Errorf("expected default route, got %q", rc.Routes.Default.Name)
+	}
+}
+
+func TestCompile_MatchPredicates(t *testing.T) {
+	rc, err := compileRoutes(t, newRoute("r", "be", &routev1.Match{
+		Sni:       "*.Prod.Example.com",
+		PathRegex: "^/api/v1/namespaces/[^/]+/pods",
+		Methods:   []string{"get", "HEAD"},
+		Headers: []*routev1.HeaderMatch{
+			{Name: "x-tenant", Regex: "^acme-"},
+			{Name: "X-Debug", Absent: true},
+		},
+		QueryParams: []*routev1.QueryParamMatch{{Name: "watch", Present: true}},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rr := rc.Routes.Ordered[0]
+	if rr.Kind != proxy.RouteMatchKindPathRegex {
+		t.Errorf("expected kind PathRegex, got %v", rr.Kind)
+	}
+	if rr.Conditions() != 6 {
+		t.Errorf("expected 6 conditions, got %d", rr.Conditions())
+	}
+	if rr.SNI != "*.prod.example.com" {
+		t.Errorf("expected lower case sni, got %q", rr.SNI)
+	}
+	if _, ok := rr.Methods["GET"]; !ok || len(rr.Methods) != 2 {
+		t.Errorf("expected upper case methods, got %v", rr.Methods)
+	}
+	if rr.Headers[0].Canonical != "X-Tenant" || rr.Headers[0].Regex == nil || !rr.Headers[1].Absent {
+		t.Errorf("unexpected header conditions %+v %+v", rr.Headers[0], rr.Headers[1])
+	}
+	if rr.QueryParams[0].Name != "watch" || !rr.QueryParams[0].Present {
+		t.Errorf("unexpected query parameter condition %+v", rr.QueryParams[0])
+	}
+}
+
+func TestCompile_MatchPredicates_InvalidRegex(t *testing.T) {
+	tests := []struct {
+		name  string
+		match *routev1.Match
+	}{
+		{name: "path", match: &routev1.Match{PathRegex: "(["}},
+		{name: "header", match: &routev1.Match{Headers: []*routev1.HeaderMatch{{Name: "X-A", Regex: "(["}}}},
+		{name: "query param", match: &routev1.Match{QueryParams: []*routev1.QueryParamMatch{{Name: "a", Regex: "(["}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRoutes(t, newRoute("r", "be", tt.match))
+			if err == nil || !strings.Contains(err.Error(), `route "r"`) {
+				t.Fatalf("expected error for route r, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCompile_RequestInfoPredicates(t *testing.T) {
+	rc, err := compileRoutes(t, newRoute("r", "be", &routev1.Match{
+		Namespace: &routev1.NamespaceMatch{Regex: "^team-a-"},
+		ApiGroups: []string{"ml.example.com", ""},
+		Resources: []string{"Jobs", "pods/log"},
+		Verbs:     []string{"get", "LIST"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rr := rc.Routes.Ordered[0]
+	if rr.Conditions() != 4 {
+		t.Errorf("expected 4 conditions, got %d", rr.Conditions())
+	}
+	if rr.Namespace == nil || rr.Namespace.Regex == nil {
+		t.Fatalf("expected namespace regex, got %+v", rr.Namespace)
+	}
+	if _, ok := rr.APIGroups[""]; !ok || len(rr.APIGroups) != 2 {
+		t.Errorf("expected core and ml.example.com groups, got %v", rr.APIGroups)
+	}
+	if _, ok := rr.Resources["jobs"]; !ok {
+		t.Errorf("expected lower case resources, got %v", rr.Resources)
+	}
+	if _, ok := rr.Verbs["list"]; !ok {
+		t.Errorf("expected lower case verbs, got %v", rr.Verbs)
+	}
+
+	_, err = compileRoutes(t, newRoute("r", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Regex: "(["}}))
+	if err == nil {
+		t.Error("expected error for invalid namespace regex")
+	}
+}
+
+func TestCompile_RequestInfoRouteOrder(t *testing.T) {
+	rc, err := compileRoutes(t,
+		newRoute("verbs", "be", &routev1.Match{Verbs: []string{"get"}}),
+		newRoute("group", "be", &routev1.Match{ApiGroups: []string{"apps"}}),
+		newRoute("resource", "be", &routev1.Match{Resources: []string{"pods"}}),
+		newRoute("ns-prefix", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Prefix: "team-"}}),
+		newRoute("ns-long-prefix", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Prefix: "team-a-"}}),
+		newRoute("ns-regex", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Regex: "^team"}}),
+		newRoute("ns-exact", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Exact: "team-a-dev"}}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, rr := range rc.Routes.Ordered {
+		got = append(got, rr.Name)
+	}
+	want := []string{"ns-exact", "ns-regex", "ns-long-prefix", "ns-prefix", "resource", "group", "verbs"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected order %v, got %v", want, got)
+	}
+}
+
+func TestCompile_AmbiguousRoutes(t *testing.T) {
+	tests := []struct {
+		name      string
+		routes    []*routev1.Route
+		ambiguous bool
+	}{
+		{
+			name: "same prefix",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{PathPrefix: "/api"}),
+				newRoute("b", "be", &routev1.Match{PathPrefix: "/api"}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "different header names",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Header: &routev1.HeaderMatch{Name: "X-A", Value: "1"}}),
+				newRoute("b", "be", &routev1.Match{Header: &routev1.HeaderMatch{Name: "X-B", Value: "1"}}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "same prefix different priority",
+			routes: []*routev1.Route{
+				withPriority(newRoute("a", "be", &routev1.Match{PathPrefix: "/api"}), 1),
+				newRoute("b", "be", &routev1.Match{PathPrefix: "/api"}),
+			},
+		},
+		{
+			name: "different sni",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Sni: "a.example.com", PathPrefix: "/api"}),
+				newRoute("b", "be", &routev1.Match{Sni: "b.example.com", PathPrefix: "/api"}),
+			},
+		},
+		{
+			name: "disjoint methods",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{PathPrefix: "/api", Methods: []string{"GET", "HEAD"}}),
+				newRoute("b", "be", &routev1.Match{PathPrefix: "/api", Methods: []string{"post"}}),
+			},
+		},
+		{
+			name: "shared method",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{PathPrefix: "/api", Methods: []string{"GET", "HEAD"}}),
+				newRoute("b", "be", &routev1.Match{PathPrefix: "/api", Methods: []string{"get"}}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "query param present and absent",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{QueryParams: []*routev1.QueryParamMatch{{Name: "watch", Present: true}}}),
+				newRoute("b", "be", &routev1.Match{QueryParams: []*routev1.QueryParamMatch{{Name: "watch", Absent: true}}}),
+			},
+		},
+		{
+			name: "different path regexes",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{PathRegex: "^/api/v1/pods"}),
+				newRoute("b", "be", &routev1.Match{PathRegex: "^/api/v1/.*"}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "different namespaces",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Exact: "a"}}),
+				newRoute("b", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Exact: "b"}}),
+			},
+		},
+		{
+			name: "same namespace prefix",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Prefix: "team-"}}),
+				newRoute("b", "be", &routev1.Match{Namespace: &routev1.NamespaceMatch{Prefix: "team-"}}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "disjoint verbs",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Verbs: []string{"get", "list", "watch"}}),
+				newRoute("b", "be", &routev1.Match{Verbs: []string{"create", "update"}}),
+			},
+		},
+		{
+			name: "overlapping api groups",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{ApiGroups: []string{"apps", "batch"}}),
+				newRoute("b", "be", &routev1.Match{ApiGroups: []string{"batch"}}),
+			},
+			ambiguous: true,
+		},
+		{
+			name: "same header different values",
+			routes: []*routev1.Route{
+				newRoute("a", "be", &routev1.Match{Header: &routev1.HeaderMatch{Name: "X-Tenant", Value: "a"}}),
+				newRoute("b", "be", &routev1.Match{Header: &routev1.HeaderMatch{Name: "x-tenant", Value: "b"}}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRoutes(t, tt.routes...)
+			if tt.ambiguous && err == nil {
+				t.Fatal("expected ambiguous routes to be rejected")
+			}
+			if !tt.ambiguous && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	backendv1 "github.com/amimof/multikube/api/backend/v1"
 	cav1 "github.com/amimof/multikube/api/ca/v1"
 	certificatev1 "github.com/amimof/multikube/api/certificate/v1"
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
 )
 
@@ -91,6 +93,58 @@ func (c *Controller) onRouteCreate(_ context.Context, r *routev1.Route) error {
 	return c.compileRuntime()
 }
 
+// onCredentialCreate also handles updates and patches, as their events carry
+// the whole credential.
+func (c *Controller) onCredentialCreate(_ context.Context, cred *credentialv1.Credential) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Info("on create handler", "credential", cred.GetMeta().GetName())
+
+	// Update cache
+	c.cache.Credentials[cred.GetMeta().GetName()] = cred
+
+	// Compile
+	return c.compileRuntime()
+}
+
+func (c *Controller) onCredentialDelete(_ context.Context, cred *credentialv1.Credential) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Info("on delete handler", "credential", cred.GetMeta().GetName())
+
+	// Update cache
+	delete(c.cache.Credentials, cred.GetMeta().GetName())
+
+	// Compile
+	return c.compileRuntime()
+}
+
+// onPolicyCreate also handles updates and patches, as their events carry the
+// whole policy.
+func (c *Controller) onPolicyCreate(_ context.Context, p *policyv1.Policy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Info("on create handler", "policy", p.GetMeta().GetName())
+
+	// Update cache
+	c.cache.Policies[p.GetMeta().GetName()] = p
+
+	// Compile
+	return c.compileRuntime()
+}
+
+func (c *Controller) onPolicyDelete(_ context.Context, p *policyv1.Policy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Info("on delete handler", "policy", p.GetMeta().GetName())
+
+	// Update cache
+	delete(c.cache.Policies, p.GetMeta().GetName())
+
+	// Compile
+	return c.compileRuntime()
+}
+
 // Compiles into runtime types and stores in store
 func (c *Controller) compileRuntime() error {
 	rt, err := c.compiler.Compile(c.cache)
@@ -131,6 +185,22 @@ func (c *Controller) onInit(ctx context.Context) error {
 		c.cache.Certificates[cert.GetMeta().GetName()] = cert
 	}
 
+	creds, err := c.clientset.CredentialV1().List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing credentials: %v", err)
+	}
+	for _, cred := range creds {
+		c.cache.Credentials[cred.GetMeta().GetName()] = cred
+	}
+
+	policies, err := c.clientset.PolicyV1().List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing policies: %v", err)
+	}
+	for _, p := range policies {
+		c.cache.Policies[p.GetMeta().GetName()] = p
+	}
+
 	routes, err := c.clientset.RouteV1().List(ctx)
 	if err != nil {
 		return fmt.Errorf("error listing routes: %v", err)
@@ -154,6 +224,14 @@ func (c *Controller) Run(ctx context.Context) {
 	// c.exchange.On(events.BackendUpdate, events.HandleErrors(c.logger, events.HandleBackends(c.onUpdate)))
 	// c.exchange.On(events.BackendPatch, events.HandleErrors(c.logger, events.HandleBackends(c.onPatch)))
 	c.exchange.On(events.RouteCreate, events.HandleErrors(c.logger, events.HandleRoutes(c.onRouteCreate)))
+	c.exchange.On(events.CredentialCreate, events.HandleErrors(c.logger, events.HandleCredentials(c.onCredentialCreate)))
+	c.exchange.On(events.CredentialUpdate, events.HandleErrors(c.logger, events.HandleCredentials(c.onCredentialCreate)))
+	c.exchange.On(events.CredentialPatch, events.HandleErrors(c.logger, events.HandleCredentials(c.onCredentialCreate)))
+	c.exchange.On(events.CredentialDelete, events.HandleErrors(c.logger, events.HandleCredentials(c.onCredentialDelete)))
+	c.exchange.On(events.PolicyCreate, events.HandleErrors(c.logger, events.HandlePolicies(c.onPolicyCreate)))
+	c.exchange.On(events.PolicyUpdate, events.HandleErrors(c.logger, events.HandlePolicies(c.onPolicyCreate)))
+	c.exchange.On(events.PolicyPatch, events.HandleErrors(c.logger, events.HandlePolicies(c.onPolicyCreate)))
+	c.exchange.On(events.PolicyDelete, events.HandleErrors(c.logger, events.HandlePolicies(c.onPolicyDelete)))
 
 	// Block until context is cancelled
 	<-ctx.Done()
@@ -169,6 +247,8 @@ func New(cs *client.ClientSet, opts ...NewOption) *Controller {
 			Routes:                 map[string]*routev1.Route{},
 			Certificates:           map[string]*certificatev1.Certificate{},
 			CertificateAuthorities: map[string]*cav1.CertificateAuthority{},
+			Credentials:            map[string]*credentialv1.Credential{},
+			Policies:               map[string]*policyv1.Policy{},
 		},
 	}
 	for _, opt := range opts {
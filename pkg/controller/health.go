@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amimof/multikube/pkg/client"
+	"github.com/amimof/multikube/pkg/logger"
+	proxyv2 "github.com/amimof/multikube/pkg/proxyv2"
+
+	backendv1 "github.com/amimof/multikube/api/backend/v1"
+)
+
+var circuitStates = map[proxyv2.CircuitState]backendv1.CircuitState{
+	proxyv2.CircuitClosed:   backendv1.CircuitState_CIRCUIT_STATE_CLOSED,
+	proxyv2.CircuitOpen:     backendv1.CircuitState_CIRCUIT_STATE_OPEN,
+	proxyv2.CircuitHalfOpen: backendv1.CircuitState_CIRCUIT_STATE_HALF_OPEN,
+}
+
+// BackendStatusWriter returns a HealthStatusFunc that writes the observed
+// health of a backend and its targets into the status of the backend.
+func BackendStatusWriter(cs *client.ClientSet, l logger.Logger) proxyv2.HealthStatusFunc {
+	return func(ctx context.Context, backend string, health proxyv2.BackendHealth) {
+		st := &backendv1.BackendStatus{
+			Healthy: health.Available(),
+			Targets: make([]*backendv1.TargetStatus, 0, len(health.Targets)),
+			Circuit: circuitStates[health.Circuit],
+		}
+
+		for _, t := range health.Targets {
+			ts := &backendv1.TargetStatus{
+				Server:  t.ID,
+				Healthy: t.Healthy,
+				Message: t.Message,
+				Ejected: t.Ejected,
+			}
+			if !t.LastProbe.IsZero() {
+				ts.LastProbe = timestamppb.New(t.LastProbe)
+			}
+			if t.Ejected {
+				ts.EjectedUntil = timestamppb.New(t.EjectedUntil)
+			}
+			st.Targets = append(st.Targets, ts)
+		}
+
+		if err := cs.BackendV1().UpdateStatus(ctx, backend, st, "healthy", "targets", "circuit"); err != nil {
+			l.Error("error updating backend status", "backend", backend, "error", err)
+		}
+	}
+}
@@ -34,6 +34,16 @@ const (
 	RouteDelete = eventv1.Event_EVENT_ROUTE_DELETE
 	RouteUpdate = eventv1.Event_EVENT_ROUTE_UPDATE
 	RoutePatch  = eventv1.Event_EVENT_ROUTE_PATCH
+
+	CredentialCreate = eventv1.Event_EVENT_CREDENTIAL_CREATE
+	CredentialDelete = eventv1.Event_EVENT_CREDENTIAL_DELETE
+	CredentialUpdate = eventv1.Event_EVENT_CREDENTIAL_UPDATE
+	CredentialPatch  = eventv1.Event_EVENT_CREDENTIAL_PATCH
+
+	PolicyCreate = eventv1.Event_EVENT_POLICY_CREATE
+	PolicyDelete = eventv1.Event_EVENT_POLICY_DELETE
+	PolicyUpdate = eventv1.Event_EVENT_POLICY_UPDATE
+	PolicyPatch  = eventv1.Event_EVENT_POLICY_PATCH
 )
 
 type Subscriber interface {
@@ -92,7 +92,7 @@ func (t *Transport) cacheResponse(res *http.Response) (bool, error) {
 	}
 	// Don't cache if response code isn't 200 (OK) or 304 (NotModified)
 	statusOK := res.StatusCode >= 200 && res.StatusCode < 300
-	if statusOK {
+	if !statusOK {
 		return false, nil
 	}
 	// Don't cache if certain url params are present (kubernetes streams)
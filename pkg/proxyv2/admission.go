@@ -0,0 +1,328 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// DefaultAdmissionMaxBodyBytes is the largest body read by Admission on
+// routes without MaxRequestBodyBytes. It is the limit of the Kubernetes API
+// server.
+const DefaultAdmissionMaxBodyBytes = 3 << 20
+
+// admissionVerbs are the verbs of requests whose objects are checked.
+var admissionVerbs = map[string]struct{}{"create": {}, "update": {}, "patch": {}}
+
+// Validation denies requests whose object does not satisfy a CEL expression.
+type Validation struct {
+	Expression string
+	// Message is returned to clients whose object fails the validation.
+	Message string
+
+	program cel.Program
+}
+
+// validationEnv declares the variables of validation expressions, those of
+// policy expressions and object.
+var validationEnv = sync.OnceValues(func() (*cel.Env, error) {
+	env, err := policyEnv()
+	if err != nil {
+		return nil, err
+	}
+	return env.Extend(cel.Variable("object", cel.MapType(cel.StringType, cel.DynType)))
+})
+
+// NewValidation returns a Validation holding for the objects for which
+// expression evaluates to true. It returns an error if expression fails to
+// compile or does not evaluate to a bool.
+func NewValidation(expression, message string) (*Validation, error) {
+	env, err := validationEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", t)
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &Validation{Expression: expression, Message: message, program: prg}, nil
+}
+
+// check returns an error describing why the object in vars fails v.
+func (v *Validation) check(vars map[string]any) error {
+	out, _, err := v.program.Eval(vars)
+	if err != nil {
+		return fmt.Errorf("validation %q failed: %v", v.Expression, err)
+	}
+	if out.Type() != types.BoolType {
+		return fmt.Errorf("validation %q must evaluate to a bool, got %s", v.Expression, out.Type())
+	}
+	if out.Value().(bool) {
+		return nil
+	}
+	if v.Message != "" {
+		return fmt.Errorf("%s", v.Message)
+	}
+	return fmt.Errorf("failed validation %q", v.Expression)
+}
+
+// Mutation sets Labels and Annotations on objects that do not have them.
+type Mutation struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// apply mutates obj and returns true if it was changed.
+func (m *Mutation) apply(obj map[string]any) bool {
+	if m == nil {
+		return false
+	}
+	labels := setDefaultMetadata(obj, "labels", m.Labels)
+	annotations := setDefaultMetadata(obj, "annotations", m.Annotations)
+	return labels || annotations
+}
+
+// setDefaultMetadata sets the values in field, labels or annotations, of the
+// metadata of obj that are not already set. It returns true if obj was
+// changed.
+func setDefaultMetadata(obj map[string]any, field string, values map[string]string) bool {
+	defaults := map[string]string{}
+	meta, _ := obj["metadata"].(map[string]any)
+	existing, _ := meta[field].(map[string]any)
+	for k, v := range values {
+		if _, ok := existing[k]; !ok {
+			defaults[k] = v
+		}
+	}
+	setMetadata(obj, field, defaults)
+	return len(defaults) > 0
+}
+
+// Admission checks the objects of create, update and patch requests with
+// policies, in the manner of admission control in the Kubernetes API server.
+// The mutations of the matching policies are applied to the objects of
+// create and update requests, after which all their validations must hold.
+//
+// Only JSON bodies can be checked, merge patches and strategic merge patches
+// for patch requests. Requests with other bodies are denied by policies with
+// validations and left unchanged by others. Requests to subresources are not
+// checked.
+type Admission struct {
+	Policies []*Policy
+}
+
+// admissionDenied is returned by Admission for requests it denies.
+type admissionDenied struct {
+	policy string
+	reason string
+}
+
+func (e *admissionDenied) Error() string {
+	return fmt.Sprintf("policy %q denied the request: %s", e.policy, e.reason)
+}
+
+// admit returns r with its object mutated. It returns an *admissionDenied
+// error if r is denied, or an *http.MaxBytesError if its body is larger than
+// the limit of route. A nil Admission admits all requests unchanged.
+func (a *Admission) admit(r *http.Request, route *RouteRuntime, now time.Time) (*http.Request, error) {
+	if a == nil {
+		return r, nil
+	}
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+	if _, ok := admissionVerbs[info.Verb]; !ok || !info.IsResourceRequest || info.Subresource != "" {
+		return r, nil
+	}
+
+	vars := policyVariables(r, routeBackend(route), now)
+	var policies []*Policy
+	for _, p := range a.Policies {
+		if !p.appliesTo(route) {
+			continue
+		}
+		ok, err := p.matches(vars)
+		if err != nil {
+			return nil, a.deny(route, p, fmt.Sprintf("policy %q failed: %v", p.Name, err))
+		}
+		if ok {
+			policies = append(policies, p)
+		}
+	}
+	if len(policies) == 0 {
+		return r, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if !checkableBody(info.Verb, mediaType) {
+		for _, p := range policies {
+			if len(p.Validations) > 0 {
+				return nil, a.deny(route, p, fmt.Sprintf("request bodies of type %q cannot be checked", mediaType))
+			}
+		}
+		return r, nil
+	}
+
+	limit := route.MaxRequestBodyBytes
+	if limit <= 0 {
+		limit = DefaultAdmissionMaxBodyBytes
+	}
+	body, err := readBody(r, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bodies that are not JSON objects are left for the backend to reject.
+	var obj map[string]any
+	if json.Valid(body) {
+		obj, _ = decodeObject(bytes.NewReader(body))
+	}
+	if obj == nil {
+		return withBody(r, body), nil
+	}
+
+	mutated := false
+	if info.Verb != "patch" {
+		for _, p := range policies {
+			if p.Mutation.apply(obj) {
+				mutated = true
+			}
+		}
+	}
+
+	vars["object"] = celValue(obj)
+	for _, p := range policies {
+		for _, v := range p.Validations {
+			if err := v.check(vars); err != nil {
+				return nil, a.deny(route, p, err.Error())
+			}
+		}
+		admissionDecisions.WithLabelValues(route.Name, p.Name, "admitted").Inc()
+	}
+
+	if mutated {
+		if out, err := encodeObject(obj); err == nil {
+			body = out
+		}
+	}
+	return withBody(r, body), nil
+}
+
+func (a *Admission) deny(route *RouteRuntime, p *Policy, reason string) error {
+	admissionDecisions.WithLabelValues(route.Name, p.Name, "denied").Inc()
+	if p.Message != "" {
+		reason = p.Message + ": " + reason
+	}
+	return &admissionDenied{policy: p.Name, reason: reason}
+}
+
+// checkableBody returns true if the objects of requests of verb with bodies
+// of mediaType can be checked.
+func checkableBody(verb, mediaType string) bool {
+	if verb == "patch" {
+		return mediaType == "application/merge-patch+json" || mediaType == "application/strategic-merge-patch+json"
+	}
+	return mediaType == "application/json"
+}
+
+// readBody reads the body of r, failing with an *http.MaxBytesError if it
+// is larger than limit.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	defer func() { _ = r.Body.Close() }()
+	if r.ContentLength > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+	return io.ReadAll(http.MaxBytesReader(nil, r.Body, limit))
+}
+
+// withBody returns a copy of r with body.
+func withBody(r *http.Request, body []byte) *http.Request {
+	out := r.Clone(r.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	out.ContentLength = int64(len(body))
+	out.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return out
+}
+
+// celValue converts the json.Number values of a decoded object into int64
+// or float64 values that CEL can compare.
+func celValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = celValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = celValue(item)
+		}
+		return out
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+// writeAdmissionError writes the response to r that failed admission with
+// err, logging denials to the audit log.
+func (p *Proxy) writeAdmissionError(w http.ResponseWriter, r *http.Request, route *RouteRuntime, err error) {
+	var denied *admissionDenied
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &denied):
+		p.auditDenied(r, route, Decision{Policy: denied.policy, Reason: denied.reason})
+		writeAdmissionDenied(w, r, denied)
+	case errors.As(err, &tooLarge):
+		writeRequestTooLarge(w, tooLarge.Limit)
+	default:
+		writeStatus(w, http.StatusBadRequest, StatusReasonBadRequest, fmt.Sprintf("read request body: %v", err))
+	}
+}
+
+// writeAdmissionDenied writes a 403 Status for r, denied by Admission.
+func writeAdmissionDenied(w http.ResponseWriter, r *http.Request, err *admissionDenied) {
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+	resource := info.Resource
+	if info.APIGroup != "" {
+		resource += "." + info.APIGroup
+	}
+	if info.Name != "" {
+		resource += " " + strconv.Quote(info.Name)
+	}
+	writeStatus(w, http.StatusForbidden, StatusReasonForbidden, fmt.Sprintf("%s is forbidden: %v", resource, err))
+}
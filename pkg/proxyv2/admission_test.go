@@ -0,0 +1,237 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func newTestValidation(t *testing.T, expression, message string) *Validation {
+	t.Helper()
+	v, err := NewValidation(expression, message)
+	if err != nil {
+		t.Fatalf("compile validation %q: %v", expression, err)
+	}
+	return v
+}
+
+// newAdmissionProxy returns a proxy checking objects with policies, and a
+// function returning the last body received by its backend.
+func newAdmissionProxy(t *testing.T, rr *RouteRuntime, policies ...*Policy) (*Proxy, func() string) {
+	t.Helper()
+	var srv *countingServer
+	srv = newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if body := srv.bodies[len(srv.bodies)-1]; r.ContentLength != int64(len(body)) {
+			t.Errorf("expected Content-Length %d, got %d", len(body), r.ContentLength)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	p := newRouteProxy(t, srv.URL, rr)
+	p.runtime.Load().Admission = &Admission{Policies: policies}
+	return p, func() string {
+		if len(srv.bodies) == 0 {
+			return ""
+		}
+		return srv.bodies[len(srv.bodies)-1]
+	}
+}
+
+func writeRequest(method, target, contentType, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+// podGuardrails denies privileged pods and pods without a team label.
+func podGuardrails(t *testing.T) *Policy {
+	p := newTestPolicy(t, "pod-guardrails", 0, `request.resource == "pods"`)
+	p.Validations = []*Validation{
+		newTestValidation(t, `!object.spec.containers.exists(c, has(c.securityContext) && has(c.securityContext.privileged) && c.securityContext.privileged)`, "privileged containers are not allowed"),
+		newTestValidation(t, `request.verb == "patch" || (has(object.metadata.labels) && has(object.metadata.labels.team))`, ""),
+	}
+	return p
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Admission
+// ---------------------------------------------------------------------------
+
+func TestAdmission_Validations(t *testing.T) {
+	p, received := newAdmissionProxy(t, &RouteRuntime{Name: "r"}, podGuardrails(t))
+
+	tests := []struct {
+		name    string
+		body    string
+		code    int
+		message string
+	}{
+		{
+			name: "admitted",
+			body: `{"kind":"Pod","metadata":{"name":"web","labels":{"team":"a"}},"spec":{"containers":[{"name":"c","securityContext":{"privileged":false}}]}}`,
+			code: http.StatusCreated,
+		},
+		{
+			name:    "privileged",
+			body:    `{"kind":"Pod","metadata":{"name":"web","labels":{"team":"a"}},"spec":{"containers":[{"name":"c","securityContext":{"privileged":true}}]}}`,
+			code:    http.StatusForbidden,
+			message: `pods is forbidden: policy "pod-guardrails" denied the request: privileged containers are not allowed`,
+		},
+		{
+			name:    "no team label",
+			body:    `{"kind":"Pod","metadata":{"name":"web"},"spec":{"containers":[{"name":"c"}]}}`,
+			code:    http.StatusForbidden,
+			message: `pods is forbidden: policy "pod-guardrails" denied the request: failed validation "request.verb == \"patch\" || (has(object.metadata.labels) && has(object.metadata.labels.team))"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, writeRequest(http.MethodPost, "/api/v1/namespaces/dev/pods", "application/json", tt.body))
+
+			if rec.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if tt.code != http.StatusForbidden {
+				if received() != tt.body {
+					t.Errorf("expected the body to be forwarded unchanged, got %s", received())
+				}
+				return
+			}
+			var st Status
+			if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil {
+				t.Fatalf("decode status: %v", err)
+			}
+			if st.Reason != StatusReasonForbidden || st.Code != http.StatusForbidden || st.Message != tt.message {
+				t.Errorf("unexpected status body: %+v", st)
+			}
+		})
+	}
+}
+
+func TestAdmission_Mutation(t *testing.T) {
+	defaults := newTestPolicy(t, "defaults", 0, `request.namespace == "dev"`)
+	defaults.Mutation = &Mutation{
+		Labels:      map[string]string{"owner": "platform", "team": "unknown"},
+		Annotations: map[string]string{"multikube.io/managed": "true"},
+	}
+	requireOwner := newTestPolicy(t, "require-owner", 0, `true`)
+	requireOwner.Validations = []*Validation{newTestValidation(t, `object.metadata.labels.owner == "platform"`, "")}
+	p, received := newAdmissionProxy(t, &RouteRuntime{Name: "r"}, defaults, requireOwner)
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, writeRequest(http.MethodPut, "/apis/apps/v1/namespaces/dev/deployments/web", "application/json",
+		`{"kind":"Deployment","metadata":{"name":"web","labels":{"team":"a"}},"spec":{"replicas":3}}`))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected the mutated object to pass validation, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var obj struct {
+		Metadata testObjectMeta         `json:"metadata"`
+		Spec     map[string]json.Number `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(received()), &obj); err != nil {
+		t.Fatalf("decode forwarded object: %v", err)
+	}
+	if obj.Metadata.Labels["owner"] != "platform" || obj.Metadata.Annotations["multikube.io/managed"] != "true" {
+		t.Errorf("expected defaults to be set, got labels %v and annotations %v", obj.Metadata.Labels, obj.Metadata.Annotations)
+	}
+	if obj.Metadata.Labels["team"] != "a" {
+		t.Errorf("expected existing labels to be kept, got %v", obj.Metadata.Labels)
+	}
+	if obj.Spec["replicas"] != "3" {
+		t.Errorf("expected the rest of the object unchanged, got %v", obj.Spec)
+	}
+}
+
+func TestAdmission_Rewrite(t *testing.T) {
+	rr := &RouteRuntime{Name: "r", Rewrite: &RewriteRuntime{Prefix: "/clusters/prod", Replacement: "/"}}
+	p, received := newAdmissionProxy(t, rr, podGuardrails(t))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, writeRequest(http.MethodPost, "/clusters/prod/api/v1/namespaces/dev/pods", "application/json",
+		`{"kind":"Pod","metadata":{"name":"web","labels":{"team":"a"}},"spec":{"containers":[{"name":"c","securityContext":{"privileged":true}}]}}`))
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected objects sent through rewritten routes to be checked, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if received() != "" {
+		t.Errorf("expected denied requests not to be forwarded, got %s", received())
+	}
+}
+
+func TestAdmission_Patch(t *testing.T) {
+	guardrails := podGuardrails(t)
+	defaults := newTestPolicy(t, "defaults", 0, `true`)
+	defaults.Mutation = &Mutation{Labels: map[string]string{"owner": "platform"}}
+
+	tests := []struct {
+		name        string
+		policies    []*Policy
+		contentType string
+		body        string
+		code        int
+	}{
+		{name: "merge patch", policies: []*Policy{guardrails, defaults}, contentType: "application/merge-patch+json", body: `{"spec":{"containers":[{"name":"c","image":"nginx"}]}}`, code: http.StatusCreated},
+		{name: "privileged merge patch", policies: []*Policy{guardrails}, contentType: "application/strategic-merge-patch+json", body: `{"spec":{"containers":[{"name":"c","securityContext":{"privileged":true}}]}}`, code: http.StatusForbidden},
+		{name: "json patch", policies: []*Policy{guardrails}, contentType: "application/json-patch+json", body: `[{"op":"add","path":"/metadata/labels/a","value":"b"}]`, code: http.StatusForbidden},
+		{name: "json patch without validations", policies: []*Policy{defaults}, contentType: "application/json-patch+json", body: `[{"op":"add","path":"/metadata/labels/a","value":"b"}]`, code: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, received := newAdmissionProxy(t, &RouteRuntime{Name: "r"}, tt.policies...)
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, writeRequest(http.MethodPatch, "/api/v1/namespaces/dev/pods/web", tt.contentType, tt.body))
+
+			if rec.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if tt.code == http.StatusCreated && received() != tt.body {
+				t.Errorf("expected patches to be forwarded unchanged, got %s", received())
+			}
+		})
+	}
+}
+
+func TestAdmission_Skipped(t *testing.T) {
+	deny := newTestPolicy(t, "deny-all", 0, `true`)
+	deny.Validations = []*Validation{newTestValidation(t, `false`, "")}
+	p, _ := newAdmissionProxy(t, &RouteRuntime{Name: "r"}, deny)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/dev/pods/web", nil),
+		writeRequest(http.MethodPut, "/api/v1/namespaces/dev/pods/web/status", "application/json", `{"status":{}}`),
+		writeRequest(http.MethodPost, "/api/v1/namespaces/dev/pods/web/eviction", "application/json", `{"kind":"Eviction"}`),
+		writeRequest(http.MethodPost, "/apis/authorization.k8s.io/v1", "application/json", `{}`),
+	} {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, req)
+		if rec.Code == http.StatusForbidden {
+			t.Errorf("%s %s: expected the request not to be checked, got %s", req.Method, req.URL.Path, rec.Body.String())
+		}
+	}
+}
+
+func TestAdmission_BodyTooLarge(t *testing.T) {
+	p, _ := newAdmissionProxy(t, &RouteRuntime{Name: "r", MaxRequestBodyBytes: 16}, podGuardrails(t))
+
+	req := writeRequest(http.MethodPost, "/api/v1/namespaces/dev/pods", "application/json", `{"kind":"Pod","metadata":{"name":"web"}}`)
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), strconv.Itoa(16)) {
+		t.Errorf("expected the limit in the Status, got %s", rec.Body.String())
+	}
+}
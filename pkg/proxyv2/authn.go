@@ -0,0 +1,120 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the request does not
+// carry any credentials that the authenticator understands.
+var ErrNoCredentials = errors.New("no credentials provided")
+
+// Identity is the authenticated identity of a client request.
+type Identity struct {
+	// Subject is the name of the authenticated user.
+	Subject string
+	// Groups are the groups the user belongs to.
+	Groups []string
+	// Extra holds additional attributes of the user, passed on to backends
+	// as Impersonate-Extra-* headers.
+	Extra map[string][]string
+	// Claims holds the verified token claims flattened into strings.
+	Claims map[string]string
+}
+
+// Authenticator verifies the credentials of an incoming request and returns
+// the identity of the caller. Implementations must return ErrNoCredentials if
+// the request has no credentials for them so that other authenticators can be
+// tried.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// AuthenticatorFunc is an adapter to allow the use of ordinary functions as
+// an Authenticator.
+type AuthenticatorFunc func(r *http.Request) (*Identity, error)
+
+// Authenticate calls f(r).
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (*Identity, error) {
+	return f(r)
+}
+
+// unionAuthenticator tries each authenticator in order and returns the first
+// successful result.
+type unionAuthenticator []Authenticator
+
+// NewUnionAuthenticator returns an Authenticator that tries authenticators in
+// order. Authenticators that report ErrNoCredentials are skipped. The first
+// error of any other kind is returned if no authenticator succeeds.
+func NewUnionAuthenticator(authenticators ...Authenticator) Authenticator {
+	return unionAuthenticator(authenticators)
+}
+
+func (u unionAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	var firstErr error
+	for _, a := range u {
+		id, err := a.Authenticate(r)
+		if err == nil {
+			return id, nil
+		}
+		if firstErr == nil && !errors.Is(err, ErrNoCredentials) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, ErrNoCredentials
+}
+
+// WithAuthentication is a middleware that authenticates every request using
+// authn. Requests that fail authentication are rejected with a Kubernetes
+// style 401 Status. On success, the identity, its subject and its claims are
+// stored in the request context.
+func WithAuthentication(authn Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := authn.Authenticate(r)
+			if err != nil {
+				authnRequests.WithLabelValues("failure").Inc()
+				w.Header().Set("WWW-Authenticate", `Bearer realm="multikube"`)
+				writeStatus(w, http.StatusUnauthorized, StatusReasonUnauthorized, "Unauthorized")
+				return
+			}
+			authnRequests.WithLabelValues("success").Inc()
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), id)))
+		})
+	}
+}
+
+// WithIdentity returns a copy of ctx carrying id, its subject and its claims.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	ctx = context.WithValue(ctx, ctxKeyIdentity, id)
+	ctx = context.WithValue(ctx, ctxKeySubject, id.Subject)
+	ctx = context.WithValue(ctx, ctxKeyJWTClaims, id.Claims)
+	return ctx
+}
+
+// IdentityFromContext returns the authenticated identity stored in ctx.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(ctxKeyIdentity).(*Identity)
+	return id, ok
+}
+
+// SubjectFromContext returns the authenticated subject stored in ctx.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	sub, ok := ctx.Value(ctxKeySubject).(string)
+	return sub, ok
+}
+
+// bearerToken returns the bearer token from the Authorization header of r.
+func bearerToken(r *http.Request) (string, bool) {
+	ah := r.Header.Get("Authorization")
+	if len(ah) < 7 || !strings.EqualFold(ah[:7], "bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(ah[7:])
+	return token, token != ""
+}
@@ -0,0 +1,243 @@
+package proxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		opts = opts.WithHeader("kid", kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, opts)
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	raw, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return raw
+}
+
+func authRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+// ---------------------------------------------------------------------------
+// Tests — WithAuthentication
+// ---------------------------------------------------------------------------
+
+func TestWithAuthentication_RS256_Valid(t *testing.T) {
+	key := newRSAKey(t)
+	token := signToken(t, key, "", map[string]any{
+		"sub":    "bob",
+		"groups": []string{"dev", "ops"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+
+	var (
+		gotSub    string
+		gotClaims map[string]string
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSub, _ = SubjectFromContext(r.Context())
+		gotClaims, _ = JWTClaimsFromContext(r.Context())
+	})
+
+	rr := httptest.NewRecorder()
+	WithAuthentication(NewRS256Authenticator(&key.PublicKey, nil))(next).ServeHTTP(rr, authRequest(token))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if gotSub != "bob" {
+		t.Errorf("expected subject %q, got %q", "bob", gotSub)
+	}
+	if gotClaims["groups"] != "dev,ops" {
+		t.Errorf("expected groups claim %q, got %q", "dev,ops", gotClaims["groups"])
+	}
+}
+
+func TestWithAuthentication_Rejected(t *testing.T) {
+	key := newRSAKey(t)
+	other := newRSAKey(t)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "no token", token: ""},
+		{name: "garbage token", token: "not-a-jwt"},
+		{name: "wrong key", token: signToken(t, other, "", map[string]any{"sub": "bob"})},
+		{name: "expired", token: signToken(t, key, "", map[string]any{"sub": "bob", "exp": time.Now().Add(-time.Hour).Unix()})},
+		{name: "no subject", token: signToken(t, key, "", map[string]any{"name": "bob"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+			rr := httptest.NewRecorder()
+			WithAuthentication(NewRS256Authenticator(&key.PublicKey, nil))(next).ServeHTTP(rr, authRequest(tt.token))
+
+			if called {
+				t.Fatal("expected next handler not to be called")
+			}
+			if rr.Code != http.StatusUnauthorized {
+				t.Fatalf("expected status 401, got %d", rr.Code)
+			}
+
+			var st Status
+			if err := json.Unmarshal(rr.Body.Bytes(), &st); err != nil {
+				t.Fatalf("decode status: %v", err)
+			}
+			if st.Kind != "Status" || st.Reason != StatusReasonUnauthorized || st.Code != http.StatusUnauthorized {
+				t.Errorf("unexpected status body: %+v", st)
+			}
+		})
+	}
+}
+
+func TestWithAuthentication_JWTRouteMatches(t *testing.T) {
+	key := newRSAKey(t)
+	token := signToken(t, key, "", map[string]any{"sub": "bob", "team": "platform"})
+
+	matched := false
+	rt := &RuntimeConfig{
+		Routes: CompiledRoutes{
+			Ordered: []*RouteRuntime{{
+				Name: "jwt",
+				Kind: RouteMatchKindJWT,
+				JWT:  &JWTRuntime{Claim: "team", Value: "platform"},
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					matched = true
+				}),
+			}},
+		},
+	}
+	store := NewRuntimeStore()
+	store.Store(rt)
+
+	rr := httptest.NewRecorder()
+	WithAuthentication(NewRS256Authenticator(&key.PublicKey, nil))(NewProxy(store)).ServeHTTP(rr, authRequest(token))
+
+	if !matched {
+		t.Fatalf("expected JWT route to match, got status %d", rr.Code)
+	}
+}
+
+func TestUnionAuthenticator_FallsThrough(t *testing.T) {
+	none := AuthenticatorFunc(func(*http.Request) (*Identity, error) { return nil, ErrNoCredentials })
+	ok := AuthenticatorFunc(func(*http.Request) (*Identity, error) { return &Identity{Subject: "alice"}, nil })
+
+	id, err := NewUnionAuthenticator(none, ok).Authenticate(authRequest(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.Subject != "alice" {
+		t.Errorf("expected subject %q, got %q", "alice", id.Subject)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — OIDC
+// ---------------------------------------------------------------------------
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key := newRSAKey(t)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(openIDConfiguration{Issuer: srv.URL, JwksURI: srv.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "k1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+
+	a := NewOIDCAuthenticator(OIDCConfig{IssuerURL: srv.URL, ClientID: "multikube", UsernameClaim: "email"})
+	if err := a.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	token := signToken(t, key, "k1", map[string]any{"iss": srv.URL, "aud": "multikube", "sub": "1234", "email": "bob@example.com"})
+	id, err := a.Authenticate(authRequest(token))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.Subject != "bob@example.com" {
+		t.Errorf("expected subject %q, got %q", "bob@example.com", id.Subject)
+	}
+
+	wrongIssuer := signToken(t, key, "k1", map[string]any{"iss": "https://evil.example.com", "aud": "multikube", "sub": "1234", "email": "bob@example.com"})
+	if _, err := a.Authenticate(authRequest(wrongIssuer)); err == nil {
+		t.Error("expected error for token from another issuer")
+	}
+
+	otherClient := signToken(t, key, "k1", map[string]any{"iss": srv.URL, "aud": []string{"other", "kubectl"}, "sub": "1234", "email": "bob@example.com"})
+	if _, err := a.Authenticate(authRequest(otherClient)); err == nil {
+		t.Error("expected error for token issued to another client")
+	}
+
+	noAudience := signToken(t, key, "k1", map[string]any{"iss": srv.URL, "sub": "1234", "email": "bob@example.com"})
+	if _, err := a.Authenticate(authRequest(noAudience)); err == nil {
+		t.Error("expected error for token without audience")
+	}
+
+	unknownKid := signToken(t, key, "k2", map[string]any{"iss": srv.URL, "aud": "multikube", "email": "bob@example.com"})
+	if _, err := a.Authenticate(authRequest(unknownKid)); err == nil {
+		t.Error("expected error for unknown key id")
+	}
+}
+
+func TestOIDCAuthenticator_IssuerMismatch(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(openIDConfiguration{Issuer: "https://evil.example.com", JwksURI: srv.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{})
+	})
+
+	a := NewOIDCAuthenticator(OIDCConfig{IssuerURL: srv.URL, ClientID: "multikube"})
+	if err := a.refresh(context.Background()); err == nil {
+		t.Error("expected error for provider metadata of another issuer")
+	}
+}
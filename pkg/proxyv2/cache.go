@@ -0,0 +1,184 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httputil"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/amimof/multikube/pkg/cache"
+)
+
+// maxCacheEntrySize is the largest response body that is cached. Larger
+// responses are streamed to the client without being cached.
+const maxCacheEntrySize = 16 << 20
+
+// isCacheable returns true for GET requests that are not long running.
+func isCacheable(r *http.Request) bool {
+	return r.Method == http.MethodGet && !isLongRunning(r) && !isUpgradeRequest(r)
+}
+
+// cacheKey returns the cache key of r. Responses depend on who is asking, so
+// the key includes the authenticated identity or, for requests that were not
+// authenticated by the proxy, a hash of the credentials they carry. The groups
+// and extra of the identity are included, as backends impersonating it answer
+// according to them. Headers that change the representation or the effective
+// user are included as well.
+func cacheKey(r *http.Request) string {
+	var b strings.Builder
+
+	if subject, ok := SubjectFromContext(r.Context()); ok && subject != "" {
+		b.WriteString("sub:")
+		b.WriteString(subject)
+		writeIdentityAttributes(&b, r)
+	} else if auth := r.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		b.WriteString("auth:")
+		b.WriteString(hex.EncodeToString(sum[:]))
+	} else {
+		b.WriteString("anonymous")
+	}
+
+	b.WriteByte('\n')
+	b.WriteString(r.URL.RequestURI())
+
+	for _, h := range []string{"Accept", "Accept-Encoding", "Impersonate-User", "Impersonate-Uid", "Impersonate-Group"} {
+		for _, v := range r.Header.Values(h) {
+			b.WriteByte('\n')
+			b.WriteString(h)
+			b.WriteByte(':')
+			b.WriteString(v)
+		}
+	}
+
+	return b.String()
+}
+
+// writeIdentityAttributes writes the sorted groups and extra of the identity
+// of r to b.
+func writeIdentityAttributes(b *strings.Builder, r *http.Request) {
+	id, ok := IdentityFromContext(r.Context())
+	if !ok {
+		return
+	}
+	for _, g := range slices.Sorted(slices.Values(id.Groups)) {
+		b.WriteString("\ngroup:")
+		b.WriteString(strconv.Quote(g))
+	}
+	for _, key := range slices.Sorted(maps.Keys(id.Extra)) {
+		for _, v := range slices.Sorted(slices.Values(id.Extra[key])) {
+			b.WriteString("\nextra:")
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte('=')
+			b.WriteString(strconv.Quote(v))
+		}
+	}
+}
+
+// serveFromCache writes a cached response for key to w. It returns false if
+// there is no cached response. If the client already has the cached
+// representation, as told by If-None-Match, 304 Not Modified is written.
+func serveFromCache(w http.ResponseWriter, r *http.Request, backend string, c *cache.Cache, key string) bool {
+	item := c.Get(key)
+	if item == nil {
+		return false
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(item.Value)), r)
+	if err != nil {
+		c.Delete(key)
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	copyHeader(w.Header(), resp.Header)
+	w.Header().Set("Age", strconv.Itoa(int(item.Age().Seconds())))
+
+	if etagMatches(r.Header.Get("If-None-Match"), resp.Header.Get("ETag")) {
+		cacheRequests.WithLabelValues(backend, "revalidated").Inc()
+		w.Header().Del("Content-Length")
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+
+	cacheRequests.WithLabelValues(backend, "hit").Inc()
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+	return true
+}
+
+// writeAndCache writes resp to w and stores it in c under key. Responses
+// without an ETag are given one derived from the body so that clients can
+// revalidate. Bodies larger than maxCacheEntrySize are streamed as is.
+func writeAndCache(w http.ResponseWriter, resp *http.Response, backend string, c *cache.Cache, key string) {
+	cacheRequests.WithLabelValues(backend, "miss").Inc()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCacheEntrySize+1))
+	if err != nil || len(body) > maxCacheEntrySize {
+		copyHeader(w.Header(), resp.Header)
+		w.WriteHeader(resp.StatusCode)
+		_, _ = w.Write(body)
+		if err == nil {
+			_, _ = io.Copy(flushWriter{ResponseWriter: w}, resp.Body)
+		}
+		return
+	}
+
+	if resp.Header.Get("ETag") == "" {
+		sum := sha256.Sum256(body)
+		resp.Header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	}
+
+	cached := &http.Response{
+		Status:        resp.Status,
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Header.Clone(),
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+	}
+	cached.Header.Del("Transfer-Encoding")
+	if b, err := httputil.DumpResponse(cached, true); err == nil {
+		c.Set(key, b)
+	}
+
+	copyHeader(w.Header(), resp.Header)
+	w.Header().Set("Age", "0")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(body)
+}
+
+// etagMatches returns true if the If-None-Match header value matches etag
+// using weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// noCache returns true if the client asked for a fresh response.
+func noCache(r *http.Request) bool {
+	for _, v := range r.Header.Values("Cache-Control") {
+		if strings.Contains(strings.ToLower(v), "no-cache") {
+			return true
+		}
+	}
+	return r.Header.Get("Pragma") == "no-cache"
+}
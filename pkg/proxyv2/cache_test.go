@@ -0,0 +1,183 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amimof/multikube/pkg/cache"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// newCachingHandler returns a handler forwarding to srv through a backend
+// that caches responses for ttl.
+func newCachingHandler(t *testing.T, srv string, ttl time.Duration) http.Handler {
+	t.Helper()
+	pool := newRetryPool(t, srv)
+	pool.Backend.CacheTTL = ttl
+	pool.Backend.Cache = cache.New(cache.WithTTL(ttl))
+	return NewForwarder(http.DefaultTransport).Handler(pool)
+}
+
+func getAs(h http.Handler, subject, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if subject != "" {
+		req = req.WithContext(WithIdentity(context.Background(), &Identity{Subject: subject}))
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+// whoami echoes the number of hits so that cached responses can be told apart.
+func whoami(cs **countingServer) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "hit %d", (*cs).hits.Load())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Response cache
+// ---------------------------------------------------------------------------
+
+func TestForwarder_Cache_Hit(t *testing.T) {
+	var srv *countingServer
+	srv = newCountingServer(t, whoami(&srv))
+	h := newCachingHandler(t, srv.URL, time.Minute)
+
+	first := getAs(h, "alice", "/api/v1/pods", nil)
+	second := getAs(h, "alice", "/api/v1/pods", nil)
+
+	if srv.hits.Load() != 1 {
+		t.Fatalf("expected 1 backend request, got %d", srv.hits.Load())
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("expected cached body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Age") == "" {
+		t.Error("expected Age header on cached response")
+	}
+	if second.Header().Get("ETag") == "" {
+		t.Error("expected ETag header on cached response")
+	}
+}
+
+func TestForwarder_Cache_PerIdentity(t *testing.T) {
+	var srv *countingServer
+	srv = newCountingServer(t, whoami(&srv))
+	h := newCachingHandler(t, srv.URL, time.Minute)
+
+	alice := getAs(h, "alice", "/api/v1/secrets", nil)
+	bob := getAs(h, "bob", "/api/v1/secrets", nil)
+	tokenA := getAs(h, "", "/api/v1/secrets", http.Header{"Authorization": {"Bearer a"}})
+	tokenB := getAs(h, "", "/api/v1/secrets", http.Header{"Authorization": {"Bearer b"}})
+
+	if srv.hits.Load() != 4 {
+		t.Fatalf("expected 4 backend requests, got %d", srv.hits.Load())
+	}
+	seen := map[string]bool{}
+	for _, rr := range []*httptest.ResponseRecorder{alice, bob, tokenA, tokenB} {
+		if seen[rr.Body.String()] {
+			t.Errorf("response %q was shared between identities", rr.Body.String())
+		}
+		seen[rr.Body.String()] = true
+	}
+}
+
+func TestCacheKey_IdentityAttributes(t *testing.T) {
+	keyOf := func(id *Identity) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/secrets", nil)
+		return cacheKey(req.WithContext(WithIdentity(context.Background(), id)))
+	}
+
+	admin := keyOf(&Identity{Subject: "alice", Groups: []string{"dev", "admins"}, Extra: map[string][]string{"team": {"a", "b"}}})
+	if got := keyOf(&Identity{Subject: "alice", Groups: []string{"admins", "dev"}, Extra: map[string][]string{"team": {"b", "a"}}}); got != admin {
+		t.Errorf("expected the key not to depend on the order of groups and extra, got %q and %q", admin, got)
+	}
+	for _, id := range []*Identity{
+		{Subject: "alice", Groups: []string{"dev"}, Extra: map[string][]string{"team": {"a", "b"}}},
+		{Subject: "alice", Groups: []string{"dev", "admins"}, Extra: map[string][]string{"team": {"a"}}},
+		{Subject: "alice", Groups: []string{"dev", "admins"}},
+	} {
+		if keyOf(id) == admin {
+			t.Errorf("expected %+v not to share responses with %q", id, admin)
+		}
+	}
+}
+
+func TestForwarder_Cache_NotCached(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		header  http.Header
+		handler func(w http.ResponseWriter, r *http.Request)
+	}{
+		{name: "watch", target: "/api/v1/pods?watch=true", handler: ok},
+		{name: "follow", target: "/api/v1/namespaces/default/pods/p/log?follow=true", handler: ok},
+		{name: "error", target: "/api/v1/pods", handler: unavailable},
+		{name: "no-cache", target: "/api/v1/pods", header: http.Header{"Cache-Control": {"no-cache"}}, handler: ok},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newCountingServer(t, tt.handler)
+			h := newCachingHandler(t, srv.URL, time.Minute)
+
+			getAs(h, "alice", tt.target, tt.header)
+			getAs(h, "alice", tt.target, tt.header)
+
+			if srv.hits.Load() != 2 {
+				t.Errorf("expected 2 backend requests, got %d", srv.hits.Load())
+			}
+		})
+	}
+}
+
+func TestForwarder_Cache_Expires(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	h := newCachingHandler(t, srv.URL, 20*time.Millisecond)
+
+	getAs(h, "alice", "/api/v1/pods", nil)
+	time.Sleep(40 * time.Millisecond)
+	getAs(h, "alice", "/api/v1/pods", nil)
+
+	if srv.hits.Load() != 2 {
+		t.Errorf("expected expired entry to be refetched, got %d backend requests", srv.hits.Load())
+	}
+}
+
+func TestForwarder_Cache_IfNoneMatch(t *testing.T) {
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("body"))
+	})
+	h := newCachingHandler(t, srv.URL, time.Minute)
+
+	getAs(h, "alice", "/api/v1/pods", nil)
+
+	rr := getAs(h, "alice", "/api/v1/pods", http.Header{"If-None-Match": {`W/"v0", "v1"`}})
+	if rr.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304, got %d", rr.Code)
+	}
+	if rr.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", rr.Body.String())
+	}
+
+	rr = getAs(h, "alice", "/api/v1/pods", http.Header{"If-None-Match": {`"v0"`}})
+	if rr.Code != http.StatusOK || rr.Body.String() != "body" {
+		t.Errorf("expected cached 200 with body, got %d %q", rr.Code, rr.Body.String())
+	}
+
+	if srv.hits.Load() != 1 {
+		t.Errorf("expected 1 backend request, got %d", srv.hits.Load())
+	}
+}
@@ -0,0 +1,204 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// ClaimMapping configures how the claims of a verified token are mapped to an
+// Identity, in the style of the claim mappings of the Kubernetes structured
+// authentication configuration. Expressions are CEL expressions over the
+// variable claims, such as claims.email or claims.sub + "@" + claims.tenant,
+// and take precedence over the corresponding claim.
+type ClaimMapping struct {
+	// UsernameClaim is the claim holding the username. Defaults to sub.
+	UsernameClaim string
+	// UsernamePrefix is prepended to usernames read from UsernameClaim, such
+	// as "oidc:", to keep them apart from other users.
+	UsernamePrefix string
+	// UsernameExpression must evaluate to a string.
+	UsernameExpression string
+
+	// GroupsClaim is the claim holding the groups of the user, either as an
+	// array or as a comma separated string. Defaults to groups.
+	GroupsClaim string
+	// GroupsPrefix is prepended to groups read from GroupsClaim.
+	GroupsPrefix string
+	// GroupsExpression must evaluate to a string or a list of strings.
+	GroupsExpression string
+
+	// ExtraClaims maps extra attributes of the user to claims. Extra
+	// attributes are passed on to backends as Impersonate-Extra-* headers.
+	ExtraClaims map[string]string
+	// ExtraExpressions maps extra attributes to expressions that must
+	// evaluate to a string or a list of strings.
+	ExtraExpressions map[string]string
+}
+
+// ClaimMapper maps the claims of verified tokens to identities.
+type ClaimMapper struct {
+	mapping ClaimMapping
+
+	username cel.Program
+	groups   cel.Program
+	extra    map[string]cel.Program
+}
+
+// NewClaimMapper returns a ClaimMapper for m. It returns an error if any of
+// the expressions of m fail to compile.
+func NewClaimMapper(m ClaimMapping) (*ClaimMapper, error) {
+	if m.UsernameClaim == "" {
+		m.UsernameClaim = "sub"
+	}
+	if m.GroupsClaim == "" {
+		m.GroupsClaim = "groups"
+	}
+
+	env, err := cel.NewEnv(cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)))
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &ClaimMapper{mapping: m, extra: map[string]cel.Program{}}
+	if cm.username, err = compileClaimExpression(env, m.UsernameExpression); err != nil {
+		return nil, fmt.Errorf("username expression: %w", err)
+	}
+	if cm.groups, err = compileClaimExpression(env, m.GroupsExpression); err != nil {
+		return nil, fmt.Errorf("groups expression: %w", err)
+	}
+	for key, expr := range m.ExtraExpressions {
+		prg, err := compileClaimExpression(env, expr)
+		if err != nil {
+			return nil, fmt.Errorf("extra expression %q: %w", key, err)
+		}
+		cm.extra[key] = prg
+	}
+	return cm, nil
+}
+
+// DefaultClaimMapper returns a ClaimMapper reading the username from the sub
+// claim and the groups from the groups claim.
+func DefaultClaimMapper() *ClaimMapper {
+	cm, _ := NewClaimMapper(ClaimMapping{})
+	return cm
+}
+
+func compileClaimExpression(env *cel.Env, expr string) (cel.Program, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	return env.Program(ast)
+}
+
+// Identity returns the identity described by claims. It returns an error if
+// no username can be derived from them.
+func (cm *ClaimMapper) Identity(claims map[string]any) (*Identity, error) {
+	id := &Identity{Claims: flattenClaims(claims)}
+
+	if cm.username != nil {
+		values, err := evalClaimExpression(cm.username, claims)
+		if err != nil {
+			return nil, fmt.Errorf("username expression: %w", err)
+		}
+		if len(values) != 1 {
+			return nil, errors.New("username expression must evaluate to a string")
+		}
+		id.Subject = values[0]
+	} else if username, ok := claims[cm.mapping.UsernameClaim].(string); ok && username != "" {
+		id.Subject = cm.mapping.UsernamePrefix + username
+	}
+	if id.Subject == "" {
+		return nil, fmt.Errorf("token has no %q claim", cm.mapping.UsernameClaim)
+	}
+
+	if cm.groups != nil {
+		groups, err := evalClaimExpression(cm.groups, claims)
+		if err != nil {
+			return nil, fmt.Errorf("groups expression: %w", err)
+		}
+		id.Groups = groups
+	} else {
+		for _, g := range claimValues(claims[cm.mapping.GroupsClaim]) {
+			id.Groups = append(id.Groups, cm.mapping.GroupsPrefix+g)
+		}
+	}
+
+	for key, claim := range cm.mapping.ExtraClaims {
+		if values := claimValues(claims[claim]); len(values) > 0 {
+			id.addExtra(key, values)
+		}
+	}
+	for key, prg := range cm.extra {
+		values, err := evalClaimExpression(prg, claims)
+		if err != nil {
+			return nil, fmt.Errorf("extra expression %q: %w", key, err)
+		}
+		id.addExtra(key, values)
+	}
+
+	return id, nil
+}
+
+func (id *Identity) addExtra(key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	if id.Extra == nil {
+		id.Extra = map[string][]string{}
+	}
+	id.Extra[key] = values
+}
+
+// evalClaimExpression evaluates prg and returns its result as a list of
+// strings. A string result is returned as a list of one.
+func evalClaimExpression(prg cel.Program, claims map[string]any) ([]string, error) {
+	out, _, err := prg.Eval(map[string]any{"claims": claims})
+	if err != nil {
+		return nil, err
+	}
+	switch out.Type() {
+	case types.StringType:
+		if s := out.Value().(string); s != "" {
+			return []string{s}, nil
+		}
+		return nil, nil
+	case types.ListType:
+		v, err := out.ConvertToNative(reflect.TypeFor[[]string]())
+		if err != nil {
+			return nil, errors.New("expression must evaluate to a string or a list of strings")
+		}
+		return v.([]string), nil
+	default:
+		return nil, fmt.Errorf("expression must evaluate to a string or a list of strings, got %s", out.Type())
+	}
+}
+
+// claimValues returns the strings of an array claim, or the comma separated
+// values of a string claim.
+func claimValues(v any) []string {
+	var out []string
+	switch val := v.(type) {
+	case string:
+		for s := range strings.SplitSeq(val, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
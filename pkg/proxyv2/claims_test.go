@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClaimMapper_Identity(t *testing.T) {
+	claims := map[string]any{
+		"sub":    "1234",
+		"email":  "alice@example.com",
+		"tenant": "acme",
+		"groups": []any{"dev", "ops"},
+		"roles":  "reader, writer",
+		"scopes": []any{"read", "write"},
+	}
+
+	tests := []struct {
+		name    string
+		mapping ClaimMapping
+		want    *Identity
+	}{
+		{
+			name:    "defaults",
+			mapping: ClaimMapping{},
+			want:    &Identity{Subject: "1234", Groups: []string{"dev", "ops"}},
+		},
+		{
+			name:    "claims with prefixes",
+			mapping: ClaimMapping{UsernameClaim: "email", UsernamePrefix: "oidc:", GroupsPrefix: "oidc:"},
+			want:    &Identity{Subject: "oidc:alice@example.com", Groups: []string{"oidc:dev", "oidc:ops"}},
+		},
+		{
+			name:    "comma separated groups",
+			mapping: ClaimMapping{GroupsClaim: "roles"},
+			want:    &Identity{Subject: "1234", Groups: []string{"reader", "writer"}},
+		},
+		{
+			name:    "extra claims",
+			mapping: ClaimMapping{ExtraClaims: map[string]string{"scopes": "scopes", "tenant": "tenant", "missing": "missing"}},
+			want: &Identity{Subject: "1234", Groups: []string{"dev", "ops"}, Extra: map[string][]string{
+				"scopes": {"read", "write"},
+				"tenant": {"acme"},
+			}},
+		},
+		{
+			name: "expressions",
+			mapping: ClaimMapping{
+				UsernameExpression: `claims.tenant + ":" + claims.email`,
+				GroupsExpression:   `claims.groups.map(g, claims.tenant + "-" + g)`,
+				ExtraExpressions:   map[string]string{"example.com/tenant": `claims.tenant`},
+			},
+			want: &Identity{Subject: "acme:alice@example.com", Groups: []string{"acme-dev", "acme-ops"}, Extra: map[string][]string{
+				"example.com/tenant": {"acme"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm, err := NewClaimMapper(tt.mapping)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := cm.Identity(claims)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got.Claims = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClaimMapper_Errors(t *testing.T) {
+	if _, err := NewClaimMapper(ClaimMapping{UsernameExpression: "claims.email +"}); err == nil {
+		t.Error("expected error for invalid expression")
+	}
+
+	tests := []struct {
+		name    string
+		mapping ClaimMapping
+	}{
+		{name: "missing username claim", mapping: ClaimMapping{UsernameClaim: "email"}},
+		{name: "username is not a string", mapping: ClaimMapping{UsernameExpression: "claims.groups"}},
+		{name: "groups are not strings", mapping: ClaimMapping{GroupsExpression: "[1, 2]"}},
+		{name: "missing claim in expression", mapping: ClaimMapping{UsernameExpression: "claims.email"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm, err := NewClaimMapper(tt.mapping)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := cm.Identity(map[string]any{"sub": "1234", "groups": []any{"dev", "ops"}}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestWithAuthentication_ClaimMapping(t *testing.T) {
+	key := newRSAKey(t)
+	token := signToken(t, key, "", map[string]any{
+		"sub":    "1234",
+		"email":  "alice@example.com",
+		"groups": "dev,ops",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+
+	cm, err := NewClaimMapper(ClaimMapping{UsernameClaim: "email", UsernamePrefix: "oidc:", GroupsPrefix: "oidc:"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got *Identity
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = IdentityFromContext(r.Context())
+	})
+	WithAuthentication(NewRS256Authenticator(&key.PublicKey, cm))(next).ServeHTTP(httptest.NewRecorder(), authRequest(token))
+
+	if got == nil {
+		t.Fatal("expected request to be authenticated")
+	}
+	if got.Subject != "oidc:alice@example.com" {
+		t.Errorf("expected subject oidc:alice@example.com, got %q", got.Subject)
+	}
+	if !slices.Equal(got.Groups, []string{"oidc:dev", "oidc:ops"}) {
+		t.Errorf("expected prefixed groups, got %v", got.Groups)
+	}
+}
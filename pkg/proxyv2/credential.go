@@ -0,0 +1,124 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TLSAuthInjector is implemented by injectors that authenticate to backends
+// during the TLS handshake rather than per request.
+type TLSAuthInjector interface {
+	RequestAuthInjector
+	// ConfigureTLS changes cfg so that connections to the backend present
+	// the credential.
+	ConfigureTLS(cfg *tls.Config)
+}
+
+// BearerTokenAuth authenticates requests with a bearer token.
+type BearerTokenAuth struct {
+	Token string
+}
+
+// Apply sets the Authorization header of req, replacing that of the client.
+func (a *BearerTokenAuth) Apply(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// BasicAuth authenticates requests with a username and password.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Apply sets the Authorization header of req, replacing that of the client.
+func (a *BasicAuth) Apply(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// ClientCertificateAuth authenticates with a client certificate presented
+// during the TLS handshake.
+type ClientCertificateAuth struct {
+	Certificate tls.Certificate
+}
+
+// Apply does nothing, the certificate is presented by ConfigureTLS.
+func (a *ClientCertificateAuth) Apply(*http.Request) error {
+	return nil
+}
+
+// ConfigureTLS sets the client certificate of cfg.
+func (a *ClientCertificateAuth) ConfigureTLS(cfg *tls.Config) {
+	cfg.Certificates = []tls.Certificate{a.Certificate}
+}
+
+// tokenFileCheckInterval is how often a token file is checked for changes.
+const tokenFileCheckInterval = time.Second
+
+// FileTokenAuth authenticates requests with a bearer token read from a file,
+// such as a projected service account token. The file is read again when its
+// modification time or size changes.
+type FileTokenAuth struct {
+	Path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+	checked time.Time
+}
+
+// Apply sets the Authorization header of req, replacing that of the client.
+func (a *FileTokenAuth) Apply(req *http.Request) error {
+	token, err := a.load(time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// load returns the token, reading the file again if it changed. The last
+// token read is returned if the file can no longer be read.
+func (a *FileTokenAuth) load(now time.Time) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && now.Sub(a.checked) < tokenFileCheckInterval {
+		return a.token, nil
+	}
+	a.checked = now
+
+	fi, err := os.Stat(a.Path)
+	if err != nil {
+		return a.cached(err)
+	}
+	if a.token != "" && fi.ModTime().Equal(a.modTime) && fi.Size() == a.size {
+		return a.token, nil
+	}
+
+	b, err := os.ReadFile(a.Path)
+	if err != nil {
+		return a.cached(err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return a.cached(fmt.Errorf("token file %s is empty", a.Path))
+	}
+
+	a.token, a.modTime, a.size = token, fi.ModTime(), fi.Size()
+	return a.token, nil
+}
+
+func (a *FileTokenAuth) cached(err error) (string, error) {
+	if a.token != "" {
+		return a.token, nil
+	}
+	return "", err
+}
@@ -0,0 +1,199 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// failingAuth is a RequestAuthInjector that always fails.
+type failingAuth struct{}
+
+func (failingAuth) Apply(*http.Request) error {
+	return errors.New("credential unavailable")
+}
+
+// forwardWith forwards a request carrying the client token client-token and
+// impersonating admin to a backend authenticating with inj and returns the response code along with
+// the headers received by the backend.
+func forwardWith(t *testing.T, inj RequestAuthInjector, mode ImpersonationMode) (int, http.Header) {
+	t.Helper()
+	var got http.Header
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	})
+
+	pool := newRetryPool(t, srv.URL)
+	pool.Backend.AuthInjector = inj
+	pool.Backend.Impersonation = mode
+	h := NewForwarder(http.DefaultTransport).Handler(pool)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.Header.Set("Authorization", "Bearer client-token")
+	req.Header.Set("Impersonate-User", "admin")
+	req.Header.Add("Impersonate-Group", "system:masters")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr.Code, got
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Credentials
+// ---------------------------------------------------------------------------
+
+func TestForwarder_Credentials(t *testing.T) {
+	tests := []struct {
+		name string
+		inj  RequestAuthInjector
+		want string
+	}{
+		{name: "none", want: "Bearer client-token"},
+		{name: "token", inj: &BearerTokenAuth{Token: "backend-token"}, want: "Bearer backend-token"},
+		{name: "basic", inj: &BasicAuth{Username: "admin", Password: "pw"}, want: "Basic YWRtaW46cHc="},
+		{name: "client certificate", inj: &ClientCertificateAuth{}, want: "Bearer client-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, got := forwardWith(t, tt.inj, ImpersonationNone)
+			if code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", code)
+			}
+			if got.Get("Authorization") != tt.want {
+				t.Errorf("expected Authorization %q, got %q", tt.want, got.Get("Authorization"))
+			}
+		})
+	}
+}
+
+func TestForwarder_Credentials_ClientImpersonation(t *testing.T) {
+	tests := []struct {
+		name string
+		inj  RequestAuthInjector
+		want string
+	}{
+		{name: "client credentials", want: "admin"},
+		{name: "token", inj: &BearerTokenAuth{Token: "backend-token"}},
+		{name: "client certificate", inj: &ClientCertificateAuth{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := forwardWith(t, tt.inj, ImpersonationNone)
+			if got.Get("Impersonate-User") != tt.want {
+				t.Errorf("expected Impersonate-User %q, got %q", tt.want, got.Get("Impersonate-User"))
+			}
+			if tt.want == "" && len(got.Values("Impersonate-Group")) != 0 {
+				t.Errorf("expected no Impersonate-Group, got %q", got.Values("Impersonate-Group"))
+			}
+		})
+	}
+}
+
+func TestForwarder_Credentials_Impersonation(t *testing.T) {
+	_, got := forwardWith(t, &BearerTokenAuth{Token: "backend-token"}, ImpersonationIdentity)
+
+	if got.Get("Authorization") != "Bearer backend-token" {
+		t.Errorf("expected the backend token, got %q", got.Get("Authorization"))
+	}
+	if got.Get("Impersonate-User") != AnonymousUser {
+		t.Errorf("expected Impersonate-User %s, got %q", AnonymousUser, got.Get("Impersonate-User"))
+	}
+}
+
+func TestForwarder_Credentials_Error(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	pool := newRetryPool(t, srv.URL)
+	pool.Backend.AuthInjector = failingAuth{}
+	pool.Backend.OutlierDetection = &OutlierDetectionRuntime{ConsecutiveErrors: 1, BaseEjectionTime: time.Minute, MaxEjectionTime: time.Minute}
+	h := NewForwarder(http.DefaultTransport).Handler(pool)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", rr.Code)
+	}
+	if srv.hits.Load() != 0 {
+		t.Error("expected the request not to reach the backend")
+	}
+	if _, ejected := pool.Targets[0].Ejected(time.Now()); ejected {
+		t.Error("expected credential errors not to eject the target")
+	}
+}
+
+func TestClientCertificateAuth_ConfigureTLS(t *testing.T) {
+	cert := tls.Certificate{Certificate: [][]byte{[]byte("der")}}
+	cfg := &tls.Config{}
+
+	var inj RequestAuthInjector = &ClientCertificateAuth{Certificate: cert}
+	tlsInj, ok := inj.(TLSAuthInjector)
+	if !ok {
+		t.Fatal("expected ClientCertificateAuth to implement TLSAuthInjector")
+	}
+	tlsInj.ConfigureTLS(cfg)
+
+	if len(cfg.Certificates) != 1 || string(cfg.Certificates[0].Certificate[0]) != "der" {
+		t.Errorf("expected the client certificate to be set, got %v", cfg.Certificates)
+	}
+}
+
+func TestFileTokenAuth_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	write := func(token string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	a := &FileTokenAuth{Path: path}
+
+	write("first", now)
+	if got, err := a.load(now); err != nil || got != "first" {
+		t.Fatalf("expected token first, got %q (%v)", got, err)
+	}
+
+	write("second", now.Add(time.Minute))
+	if got, _ := a.load(now); got != "first" {
+		t.Errorf("expected the file not to be checked again right away, got %q", got)
+	}
+	if got, _ := a.load(now.Add(tokenFileCheckInterval)); got != "second" {
+		t.Errorf("expected the changed file to be read again, got %q", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := a.load(now.Add(2 * tokenFileCheckInterval)); err != nil || got != "second" {
+		t.Errorf("expected the last token when the file is gone, got %q (%v)", got, err)
+	}
+}
+
+func TestFileTokenAuth_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing"), empty} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+		if err := (&FileTokenAuth{Path: path}).Apply(req); err == nil {
+			t.Errorf("expected an error for %s", path)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultDecorationMaxBodyBytes is the largest body decorated by decorations
+// without MaxBodyBytes.
+const DefaultDecorationMaxBodyBytes = 64 << 20
+
+// DecorationRuntime sets Labels and Annotations on the objects returned by a
+// backend. Only JSON is decorated.
+type DecorationRuntime struct {
+	Labels      map[string]string
+	Annotations map[string]string
+	// MaxBodyBytes is the largest body read into memory to be decorated.
+	// Larger bodies, other than watches, are returned undecorated. Defaults
+	// to DefaultDecorationMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// decoration returns the decoration of the backend of the pool, or nil if it
+// has none.
+func (p *BackendPool) decoration() *DecorationRuntime {
+	if p.Backend == nil {
+		return nil
+	}
+	return p.Backend.Decoration
+}
+
+// prepare returns a copy of r asking for an uncompressed response, as JSON if
+// the client accepts it, so that the response can be decorated.
+func (d *DecorationRuntime) prepare(r *http.Request) *http.Request {
+	if d == nil || r.Method != http.MethodGet {
+		return r
+	}
+
+	accept := r.Header.Get("Accept")
+	preferJSON := accept != "" && acceptsJSON(accept)
+	if !preferJSON && r.Header.Get("Accept-Encoding") == "" {
+		return r
+	}
+
+	out := r.Clone(r.Context())
+	out.Header.Del("Accept-Encoding")
+	if preferJSON {
+		out.Header.Set("Accept", jsonAccept(accept))
+	}
+	return out
+}
+
+// decorate replaces the body of resp with one that has its objects
+// decorated. Only successful JSON responses to GET requests for resources are
+// decorated. Watch events are decorated as they are streamed, other bodies
+// larger than MaxBodyBytes are streamed undecorated.
+func (d *DecorationRuntime) decorate(r *http.Request, resp *http.Response) {
+	if d == nil || r.Method != http.MethodGet || resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Encoding") != "" {
+		return
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		return
+	}
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+	if !info.IsResourceRequest {
+		return
+	}
+
+	if info.Verb == "watch" {
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Body = d.decorateWatch(resp.Body)
+		return
+	}
+
+	limit := d.maxBodyBytes()
+	if resp.ContentLength > limit {
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err == nil && int64(len(body)) > limit {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		return
+	}
+
+	if obj, err := decodeObject(bytes.NewReader(body)); err == nil {
+		d.decorateObject(obj)
+		if out, err := encodeObject(obj); err == nil {
+			body = out
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+}
+
+func (d *DecorationRuntime) maxBodyBytes() int64 {
+	if d.MaxBodyBytes <= 0 {
+		return DefaultDecorationMaxBodyBytes
+	}
+	return d.MaxBodyBytes
+}
+
+// decorateWatch returns a body that decorates the objects of the watch events
+// read from body.
+func (d *DecorationRuntime) decorateWatch(body io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		dec := json.NewDecoder(body)
+		dec.UseNumber()
+		for {
+			var event map[string]any
+			if err := dec.Decode(&event); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				_ = pw.CloseWithError(err)
+				return
+			}
+
+			if obj, ok := event["object"].(map[string]any); ok && event["type"] != "ERROR" && event["type"] != "BOOKMARK" {
+				d.decorateObject(obj)
+			}
+
+			out, err := encodeObject(event)
+			if err == nil {
+				_, err = pw.Write(out)
+			}
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return &watchBody{PipeReader: pr, body: body}
+}
+
+// watchBody is the body of a decorated watch. Closing it stops the
+// decoration and closes the original body.
+type watchBody struct {
+	*io.PipeReader
+	body io.ReadCloser
+}
+
+func (b *watchBody) Close() error {
+	_ = b.PipeReader.Close()
+	return b.body.Close()
+}
+
+// decorateObject decorates obj, the items of a list or the objects of the
+// rows of a Table.
+func (d *DecorationRuntime) decorateObject(obj map[string]any) {
+	kind, _ := obj["kind"].(string)
+	switch {
+	case kind == "Status":
+	case kind == "Table":
+		rows, _ := obj["rows"].([]any)
+		for _, row := range rows {
+			if row, ok := row.(map[string]any); ok {
+				if o, ok := row["object"].(map[string]any); ok {
+					d.setMetadata(o)
+				}
+			}
+		}
+	case strings.HasSuffix(kind, "List"):
+		items, _ := obj["items"].([]any)
+		for _, item := range items {
+			if item, ok := item.(map[string]any); ok {
+				d.setMetadata(item)
+			}
+		}
+	default:
+		d.setMetadata(obj)
+	}
+}
+
+func (d *DecorationRuntime) setMetadata(obj map[string]any) {
+	setMetadata(obj, "labels", d.Labels)
+	setMetadata(obj, "annotations", d.Annotations)
+}
+
+// setMetadata sets values in field, labels or annotations, of the metadata
+// of obj.
+func setMetadata(obj map[string]any, field string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	meta, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		meta = map[string]any{}
+		obj["metadata"] = meta
+	}
+	m, ok := meta[field].(map[string]any)
+	if !ok {
+		m = map[string]any{}
+		meta[field] = m
+	}
+	for k, v := range values {
+		m[k] = v
+	}
+}
+
+// decodeObject decodes a JSON object keeping numbers as json.Number, so that
+// they are encoded unchanged.
+func decodeObject(r io.Reader) (map[string]any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// encodeObject encodes obj the way the Kubernetes API server does, followed
+// by a newline.
+func encodeObject(obj any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
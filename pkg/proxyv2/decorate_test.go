@@ -0,0 +1,282 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+var testDecoration = &DecorationRuntime{
+	Labels:      map[string]string{"cluster": "prod-eu"},
+	Annotations: map[string]string{"multikube.io/region": "eu-west-1"},
+}
+
+// newDecoratingHandler returns a forwarder handler to a backend served by
+// handler and decorated with testDecoration.
+func newDecoratingHandler(t *testing.T, handler http.HandlerFunc) http.Handler {
+	t.Helper()
+	srv := newCountingServer(t, handler)
+	pool := newRetryPool(t, srv.URL)
+	pool.Backend.Decoration = testDecoration
+	return NewForwarder(http.DefaultTransport).Handler(pool)
+}
+
+type testObjectMeta struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+func assertDecorated(t *testing.T, meta testObjectMeta) {
+	t.Helper()
+	if meta.Labels["cluster"] != "prod-eu" || meta.Annotations["multikube.io/region"] != "eu-west-1" {
+		t.Errorf("expected %s to be decorated, got labels %v and annotations %v", meta.Name, meta.Labels, meta.Annotations)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Decoration
+// ---------------------------------------------------------------------------
+
+func TestDecoration_Object(t *testing.T) {
+	h := newDecoratingHandler(t, respond(http.StatusOK, `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","labels":{"app":"web"}},"spec":{"priority":2000000000}}`))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/pods/web", nil))
+
+	var pod struct {
+		Metadata testObjectMeta         `json:"metadata"`
+		Spec     map[string]json.Number `json:"spec"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &pod); err != nil {
+		t.Fatalf("decode pod: %v", err)
+	}
+	assertDecorated(t, pod.Metadata)
+	if pod.Metadata.Labels["app"] != "web" {
+		t.Errorf("expected the labels of the object to be kept, got %v", pod.Metadata.Labels)
+	}
+	if pod.Spec["priority"] != "2000000000" {
+		t.Errorf("expected numbers to be kept unchanged, got %s", pod.Spec["priority"])
+	}
+	if got := rr.Header().Get("Content-Length"); got != strconv.Itoa(rr.Body.Len()) {
+		t.Errorf("expected Content-Length %d, got %s", rr.Body.Len(), got)
+	}
+}
+
+func TestDecoration_Lists(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		metas func(body []byte) ([]testObjectMeta, error)
+	}{
+		{
+			name: "list",
+			body: `{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]}`,
+			metas: func(body []byte) ([]testObjectMeta, error) {
+				var list struct {
+					Items []struct {
+						Metadata testObjectMeta `json:"metadata"`
+					} `json:"items"`
+				}
+				err := json.Unmarshal(body, &list)
+				var out []testObjectMeta
+				for _, item := range list.Items {
+					out = append(out, item.Metadata)
+				}
+				return out, err
+			},
+		},
+		{
+			name: "table",
+			body: `{"kind":"Table","apiVersion":"meta.k8s.io/v1","metadata":{},"columnDefinitions":[],"rows":[{"cells":["a"],"object":{"metadata":{"name":"a"}}}]}`,
+			metas: func(body []byte) ([]testObjectMeta, error) {
+				var table struct {
+					Rows []struct {
+						Object struct {
+							Metadata testObjectMeta `json:"metadata"`
+						} `json:"object"`
+					} `json:"rows"`
+				}
+				err := json.Unmarshal(body, &table)
+				var out []testObjectMeta
+				for _, row := range table.Rows {
+					out = append(out, row.Object.Metadata)
+				}
+				return out, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDecoratingHandler(t, respond(http.StatusOK, tt.body))
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+			metas, err := tt.metas(rr.Body.Bytes())
+			if err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(metas) == 0 {
+				t.Fatal("expected objects in the response")
+			}
+			for _, meta := range metas {
+				assertDecorated(t, meta)
+			}
+		})
+	}
+}
+
+func TestDecoration_Watch(t *testing.T) {
+	next := make(chan struct{})
+	h := newDecoratingHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"type":"ADDED","object":{"kind":"Pod","metadata":{"name":"a"}}}`+"\n")
+		w.(http.Flusher).Flush()
+		<-next
+		_, _ = io.WriteString(w, `{"type":"BOOKMARK","object":{"kind":"Pod","metadata":{"resourceVersion":"12"}}}`+"\n")
+		_, _ = io.WriteString(w, `{"type":"MODIFIED","object":{"kind":"Pod","metadata":{"name":"a"}}}`+"\n")
+	})
+	front := httptest.NewServer(h)
+	t.Cleanup(front.Close)
+
+	resp, err := http.Get(front.URL + "/api/v1/pods?watch=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	type event struct {
+		Type   string `json:"type"`
+		Object struct {
+			Metadata testObjectMeta `json:"metadata"`
+		} `json:"object"`
+	}
+	lines := bufio.NewScanner(resp.Body)
+	read := func() event {
+		t.Helper()
+		if !lines.Scan() {
+			t.Fatalf("expected another event: %v", lines.Err())
+		}
+		var e event
+		if err := json.Unmarshal(lines.Bytes(), &e); err != nil {
+			t.Fatalf("decode event %q: %v", lines.Text(), err)
+		}
+		return e
+	}
+
+	// The first event is decorated while the backend is still streaming.
+	assertDecorated(t, read().Object.Metadata)
+	close(next)
+
+	if e := read(); e.Type != "BOOKMARK" || len(e.Object.Metadata.Labels) != 0 {
+		t.Errorf("expected the bookmark to be left as is, got %+v", e)
+	}
+	assertDecorated(t, read().Object.Metadata)
+	if lines.Scan() {
+		t.Errorf("expected the watch to end, got %q", lines.Text())
+	}
+}
+
+func TestDecoration_Unchanged(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		code   int
+		ctype  string
+		body   string
+	}{
+		{name: "protobuf", target: "/api/v1/pods", code: http.StatusOK, ctype: "application/vnd.kubernetes.protobuf", body: "k8s\x00\x0a"},
+		{name: "error", target: "/api/v1/namespaces/default/pods/web", code: http.StatusNotFound, ctype: "application/json", body: `{"kind":"Status","code":404}`},
+		{name: "non-resource", target: "/version", code: http.StatusOK, ctype: "application/json", body: `{"major":"1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDecoratingHandler(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.ctype)
+				w.WriteHeader(tt.code)
+				_, _ = io.WriteString(w, tt.body)
+			})
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rr.Body.String() != tt.body {
+				t.Errorf("expected the body to be unchanged, got %q", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestDecoration_MaxBodyBytes(t *testing.T) {
+	const body = `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web"}}`
+	tests := []struct {
+		name    string
+		chunked bool
+	}{
+		{name: "content length"},
+		{name: "chunked", chunked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if !tt.chunked {
+					w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+				}
+				_, _ = io.WriteString(w, body[:10])
+				w.(http.Flusher).Flush()
+				_, _ = io.WriteString(w, body[10:])
+			})
+			pool := newRetryPool(t, srv.URL)
+			pool.Backend.Decoration = &DecorationRuntime{Labels: testDecoration.Labels, MaxBodyBytes: 16}
+			rr := httptest.NewRecorder()
+			NewForwarder(http.DefaultTransport).Handler(pool).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/pods/web", nil))
+
+			if rr.Body.String() != body {
+				t.Errorf("expected bodies larger than MaxBodyBytes to be unchanged, got %q", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestDecoration_Prepare(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "protobuf with json fallback", accept: "application/vnd.kubernetes.protobuf,application/json", want: "application/json"},
+		{name: "table", accept: "application/json;as=Table;v=v1;g=meta.k8s.io,application/json", want: "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"},
+		{name: "protobuf only", accept: "application/vnd.kubernetes.protobuf", want: "application/vnd.kubernetes.protobuf"},
+		{name: "none", accept: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			out := testDecoration.prepare(req)
+			if got := out.Header.Get("Accept"); got != tt.want {
+				t.Errorf("expected Accept %q, got %q", tt.want, got)
+			}
+			if out.Header.Get("Accept-Encoding") != "" {
+				t.Error("expected Accept-Encoding to be removed")
+			}
+			if req.Header.Get("Accept-Encoding") != "gzip" {
+				t.Error("expected the request of the client to be left as is")
+			}
+		})
+	}
+}
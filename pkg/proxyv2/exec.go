@@ -0,0 +1,204 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultExecAPIVersion is the version of ExecCredential exchanged with
+	// credential plugins unless configured otherwise.
+	DefaultExecAPIVersion = "client.authentication.k8s.io/v1"
+	// DefaultExecRefreshBefore is how long before expiry tokens of credential
+	// plugins are refreshed.
+	DefaultExecRefreshBefore = time.Minute
+	// DefaultExecTimeout bounds how long a credential plugin may run.
+	DefaultExecTimeout = 30 * time.Second
+
+	// execRetryInterval is how long a failed plugin is not run again.
+	execRetryInterval = 5 * time.Second
+)
+
+// execCredential is the ExecCredential object of the
+// client.authentication.k8s.io API group.
+type execCredential struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Spec       execCredentialSpec    `json:"spec"`
+	Status     *execCredentialStatus `json:"status,omitempty"`
+}
+
+type execCredentialSpec struct {
+	Interactive bool `json:"interactive"`
+}
+
+type execCredentialStatus struct {
+	ExpirationTimestamp   *time.Time `json:"expirationTimestamp,omitempty"`
+	Token                 string     `json:"token,omitempty"`
+	ClientCertificateData string     `json:"clientCertificateData,omitempty"`
+	ClientKeyData         string     `json:"clientKeyData,omitempty"`
+}
+
+// ExecAuth authenticates requests with bearer tokens produced by a
+// client.authentication.k8s.io credential plugin, the same kind of plugin
+// used by kubectl. Tokens are cached until their expirationTimestamp and
+// refreshed in the background RefreshBefore ahead of expiry. Tokens without
+// expiry are cached for as long as the ExecAuth is in use.
+type ExecAuth struct {
+	Command string
+	Args    []string
+	// Env holds NAME=value pairs set in addition to the environment of the
+	// process.
+	Env []string
+	// APIVersion defaults to DefaultExecAPIVersion.
+	APIVersion string
+	// RefreshBefore defaults to DefaultExecRefreshBefore.
+	RefreshBefore time.Duration
+
+	mu         sync.Mutex
+	token      string
+	expiry     time.Time
+	err        error
+	failed     time.Time
+	refreshing chan struct{}
+}
+
+// Apply sets the Authorization header of req, replacing that of the client.
+// It runs the plugin if there is no valid token.
+func (a *ExecAuth) Apply(req *http.Request) error {
+	token, err := a.get(req.Context(), time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// get returns a valid token. It waits for the plugin if there is none, and
+// starts a refresh without waiting if the token is about to expire.
+func (a *ExecAuth) get(ctx context.Context, now time.Time) (string, error) {
+	a.mu.Lock()
+	backoff := a.err != nil && now.Sub(a.failed) < execRetryInterval
+	if a.valid(now) {
+		token := a.token
+		if a.expiring(now) && !backoff {
+			a.refresh()
+		}
+		a.mu.Unlock()
+		return token, nil
+	}
+	if backoff {
+		err := a.err
+		a.mu.Unlock()
+		return "", err
+	}
+	done := a.refresh()
+	a.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err != nil {
+		return "", a.err
+	}
+	return a.token, nil
+}
+
+func (a *ExecAuth) valid(now time.Time) bool {
+	return a.token != "" && (a.expiry.IsZero() || now.Before(a.expiry))
+}
+
+func (a *ExecAuth) expiring(now time.Time) bool {
+	refreshBefore := a.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultExecRefreshBefore
+	}
+	return !a.expiry.IsZero() && !now.Before(a.expiry.Add(-refreshBefore))
+}
+
+// refresh runs the plugin unless it is already running and returns a channel
+// closed once it is done. a.mu must be held.
+func (a *ExecAuth) refresh() <-chan struct{} {
+	if a.refreshing != nil {
+		return a.refreshing
+	}
+	done := make(chan struct{})
+	a.refreshing = done
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultExecTimeout)
+		defer cancel()
+		token, expiry, err := a.run(ctx)
+
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.err = err
+		if err != nil {
+			a.failed = time.Now()
+		} else {
+			a.token, a.expiry = token, expiry
+		}
+		a.refreshing = nil
+		close(done)
+	}()
+	return done
+}
+
+// run executes the plugin and returns the token it produced.
+func (a *ExecAuth) run(ctx context.Context) (string, time.Time, error) {
+	apiVersion := a.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultExecAPIVersion
+	}
+
+	info, err := json.Marshal(&execCredential{APIVersion: apiVersion, Kind: "ExecCredential"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, a.Command, a.Args...)
+	cmd.Env = append(os.Environ(), a.Env...)
+	cmd.Env = append(cmd.Env, "KUBERNETES_EXEC_INFO="+string(info))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", time.Time{}, fmt.Errorf("exec plugin %s: %w: %s", a.Command, err, msg)
+		}
+		return "", time.Time{}, fmt.Errorf("exec plugin %s: %w", a.Command, err)
+	}
+
+	var cred execCredential
+	if err := json.Unmarshal(stdout.Bytes(), &cred); err != nil {
+		return "", time.Time{}, fmt.Errorf("exec plugin %s: decode ExecCredential: %w", a.Command, err)
+	}
+	if cred.APIVersion != apiVersion || cred.Kind != "ExecCredential" {
+		return "", time.Time{}, fmt.Errorf("exec plugin %s: expected ExecCredential of %s, got %s of %s", a.Command, apiVersion, cred.Kind, cred.APIVersion)
+	}
+	if cred.Status == nil || cred.Status.Token == "" {
+		if cred.Status != nil && cred.Status.ClientCertificateData != "" {
+			return "", time.Time{}, fmt.Errorf("exec plugin %s: client certificates are not supported", a.Command)
+		}
+		return "", time.Time{}, fmt.Errorf("exec plugin %s returned no token", a.Command)
+	}
+
+	var expiry time.Time
+	if cred.Status.ExpirationTimestamp != nil {
+		expiry = *cred.Status.ExpirationTimestamp
+	}
+	return cred.Status.Token, expiry, nil
+}
@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// execPlugin writes a credential plugin to a temporary directory. The plugin
+// returns the token token-N on its N:th run, expiring at $EXPIRY if set, and
+// records KUBERNETES_EXEC_INFO and $GREETING next to it.
+func execPlugin(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+dir=$(dirname "$0")
+n=$(cat "$dir/count" 2>/dev/null || echo 0)
+n=$((n+1))
+echo "$n" > "$dir/count"
+echo "$KUBERNETES_EXEC_INFO" > "$dir/info"
+echo "$GREETING" > "$dir/greeting"
+expiry=""
+if [ -n "$EXPIRY" ]; then expiry=",\"expirationTimestamp\":\"$EXPIRY\""; fi
+printf '{"apiVersion":"%s","kind":"ExecCredential","status":{"token":"token-%s"%s}}' "${API_VERSION:-client.authentication.k8s.io/v1}" "$n" "$expiry"
+`
+	path := filepath.Join(dir, "plugin")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path, dir
+}
+
+func readPluginFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func applyExec(t *testing.T, a *ExecAuth, now time.Time) string {
+	t.Helper()
+	token, err := a.get(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return token
+}
+
+// waitRefreshed waits for a running refresh of a to finish.
+func waitRefreshed(a *ExecAuth) {
+	a.mu.Lock()
+	done := a.refreshing
+	a.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Exec credential plugins
+// ---------------------------------------------------------------------------
+
+func TestExecAuth_Apply(t *testing.T) {
+	path, dir := execPlugin(t)
+	a := &ExecAuth{Command: path, Env: []string{"GREETING=hello"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	if err := a.Apply(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer token-1" {
+		t.Errorf("expected Authorization Bearer token-1, got %q", got)
+	}
+	if got := readPluginFile(t, dir, "greeting"); got != "hello" {
+		t.Errorf("expected the environment to be passed to the plugin, got %q", got)
+	}
+	if got := readPluginFile(t, dir, "info"); !strings.Contains(got, `"kind":"ExecCredential"`) || !strings.Contains(got, DefaultExecAPIVersion) {
+		t.Errorf("expected KUBERNETES_EXEC_INFO to hold an ExecCredential, got %q", got)
+	}
+}
+
+func TestExecAuth_CachesToken(t *testing.T) {
+	path, dir := execPlugin(t)
+	a := &ExecAuth{Command: path}
+	now := time.Now()
+
+	applyExec(t, a, now)
+	if got := applyExec(t, a, now.Add(24*time.Hour)); got != "token-1" {
+		t.Errorf("expected a token without expiry to be cached, got %q", got)
+	}
+	if got := readPluginFile(t, dir, "count"); got != "1" {
+		t.Errorf("expected the plugin to run once, ran %s times", got)
+	}
+}
+
+func TestExecAuth_Refresh(t *testing.T) {
+	path, _ := execPlugin(t)
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	a := &ExecAuth{
+		Command:       path,
+		Env:           []string{"EXPIRY=" + expiry.Format(time.RFC3339)},
+		RefreshBefore: 10 * time.Minute,
+	}
+
+	if got := applyExec(t, a, expiry.Add(-time.Hour)); got != "token-1" {
+		t.Fatalf("expected token-1, got %q", got)
+	}
+	if !a.expiry.Equal(expiry) {
+		t.Fatalf("expected expiry %s, got %s", expiry, a.expiry)
+	}
+
+	// Ahead of expiry the current token is used while it is refreshed.
+	if got := applyExec(t, a, expiry.Add(-5*time.Minute)); got != "token-1" {
+		t.Errorf("expected the current token while refreshing, got %q", got)
+	}
+	waitRefreshed(a)
+	if got := applyExec(t, a, expiry.Add(-5*time.Minute)); got != "token-2" {
+		t.Errorf("expected the refreshed token, got %q", got)
+	}
+
+	// Past expiry requests wait for a new token.
+	if got := applyExec(t, a, expiry.Add(time.Minute)); got != "token-3" {
+		t.Errorf("expected a new token once expired, got %q", got)
+	}
+}
+
+func TestExecAuth_Errors(t *testing.T) {
+	path, _ := execPlugin(t)
+
+	tests := []struct {
+		name string
+		auth *ExecAuth
+		want string
+	}{
+		{name: "command fails", auth: &ExecAuth{Command: "false"}, want: "exec plugin false"},
+		{name: "wrong version", auth: &ExecAuth{Command: path, Env: []string{"API_VERSION=v0"}}, want: "expected ExecCredential"},
+		{name: "not found", auth: &ExecAuth{Command: filepath.Join(t.TempDir(), "missing")}, want: "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.auth.get(context.Background(), time.Now())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+
+			// Failed plugins are not run again right away.
+			if _, again := tt.auth.get(context.Background(), time.Now()); again != err {
+				t.Errorf("expected the previous error during back-off, got %v", again)
+			}
+		})
+	}
+}
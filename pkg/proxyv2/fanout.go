@@ -0,0 +1,364 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultClusterAnnotation is the annotation set on items of fanned out lists
+// to the name of the backend they were listed from.
+const DefaultClusterAnnotation = "multikube.io/cluster"
+
+// DefaultFanOutMaxListBytes is the largest list read from every backend of
+// fan-outs without MaxListBytes.
+const DefaultFanOutMaxListBytes = 64 << 20
+
+// FanOutRuntime sends list requests to several backends in parallel and
+// merges the returned lists into one.
+type FanOutRuntime struct {
+	Backends []*FanOutBackend
+	// ClusterAnnotation is set on every item to the name of its backend.
+	ClusterAnnotation string
+	// MaxListBytes is the largest list read from every backend. Backends
+	// returning larger lists are left out. Defaults to
+	// DefaultFanOutMaxListBytes.
+	MaxListBytes int64
+}
+
+// FanOutBackend is a backend lists are gathered from.
+type FanOutBackend struct {
+	Name    string
+	Handler http.Handler
+	// Backend is the backend the handler forwards to, as seen by policies.
+	Backend *BackendRuntime
+}
+
+// clusterColumn is added to Table responses so that kubectl shows the
+// backend of every row.
+var clusterColumn = map[string]any{
+	"name":        "Cluster",
+	"type":        "string",
+	"format":      "",
+	"description": "The backend the object was listed from.",
+	"priority":    0,
+}
+
+// FanOutHandler returns a handler that sends list requests to every backend
+// of fo and other requests to next.
+//
+// Lists are requested as JSON without limit and continue, as continue tokens
+// of one backend mean nothing to another. Items are returned in the order of
+// the backends and the metadata of the merged list, such as its
+// resourceVersion, is left empty. Backends that fail are left out of the
+// response and reported in a Warning header, as are backends returning lists
+// larger than MaxListBytes. If all of them fail, the response of the first
+// is returned, or 413 if its list is too large.
+func FanOutHandler(fo *FanOutRuntime, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !fo.fansOut(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		responses := make([]*bufferedResponse, len(fo.Backends))
+		var wg sync.WaitGroup
+		for i, b := range fo.Backends {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				responses[i] = newBufferedResponse(fo.maxListBytes())
+				b.Handler.ServeHTTP(responses[i], fanOutRequest(r))
+			}()
+		}
+		wg.Wait()
+
+		fo.merge(w, responses)
+	})
+}
+
+// fansOut returns true if r is sent to the backends of fo, which is the case
+// for list requests. A nil FanOutRuntime fans out no request.
+func (fo *FanOutRuntime) fansOut(r *http.Request) bool {
+	if fo == nil {
+		return false
+	}
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+	return info.IsResourceRequest && info.Verb == "list"
+}
+
+func (fo *FanOutRuntime) maxListBytes() int64 {
+	if fo.MaxListBytes <= 0 {
+		return DefaultFanOutMaxListBytes
+	}
+	return fo.MaxListBytes
+}
+
+// fanOutRequest returns a copy of r asking for the whole list as JSON.
+func fanOutRequest(r *http.Request) *http.Request {
+	out := r.Clone(r.Context())
+
+	q := out.URL.Query()
+	q.Del("limit")
+	q.Del("continue")
+	out.URL.RawQuery = q.Encode()
+
+	out.Header.Set("Accept", jsonAccept(r.Header.Get("Accept")))
+	// Let the transport decompress responses so that they can be merged.
+	out.Header.Del("Accept-Encoding")
+	return out
+}
+
+// jsonAccept removes the media types other than JSON from an Accept header,
+// keeping parameters such as those asking for a Table.
+func jsonAccept(accept string) string {
+	var out []string
+	for _, v := range strings.Split(accept, ",") {
+		if v = strings.TrimSpace(v); isJSONMediaRange(v) {
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return "application/json"
+	}
+	return strings.Join(out, ",")
+}
+
+// acceptsJSON returns true if an Accept header lists JSON.
+func acceptsJSON(accept string) bool {
+	return slices.ContainsFunc(strings.Split(accept, ","), isJSONMediaRange)
+}
+
+func isJSONMediaRange(v string) bool {
+	mediaType, _, _ := strings.Cut(strings.TrimSpace(v), ";")
+	return mediaType == "application/json" || mediaType == "*/*"
+}
+
+// merge writes the lists in responses as one.
+func (fo *FanOutRuntime) merge(w http.ResponseWriter, responses []*bufferedResponse) {
+	var (
+		merged   map[string]json.RawMessage
+		kind     string
+		items    []any
+		warnings []string
+		failed   *bufferedResponse
+	)
+
+	for i, res := range responses {
+		name := fo.Backends[i].Name
+		if res.tooLarge {
+			fanOutRequests.WithLabelValues(name, "failure").Inc()
+			warnings = append(warnings, warningHeader(fmt.Sprintf("backend %q returned a list larger than %d bytes", name, res.limit)))
+			if failed == nil {
+				failed = res
+			}
+			continue
+		}
+		if res.code != http.StatusOK {
+			fanOutRequests.WithLabelValues(name, "failure").Inc()
+			warnings = append(warnings, warningHeader(fmt.Sprintf("backend %q failed: %s", name, failureMessage(res))))
+			if failed == nil {
+				failed = res
+			}
+			continue
+		}
+
+		list, listKind, listItems, err := decodeList(res.body.Bytes())
+		if err == nil && merged != nil && listKind != kind {
+			err = fmt.Errorf("expected %s, got %s", kind, listKind)
+		}
+		if err != nil {
+			fanOutRequests.WithLabelValues(name, "failure").Inc()
+			warnings = append(warnings, warningHeader(fmt.Sprintf("backend %q returned no list: %v", name, err)))
+			continue
+		}
+		fanOutRequests.WithLabelValues(name, "success").Inc()
+
+		if merged == nil {
+			merged, kind = list, listKind
+		}
+		for _, item := range listItems {
+			items = append(items, fo.annotate(item, listKind, name))
+		}
+		warnings = append(warnings, res.header.Values("Warning")...)
+	}
+
+	if merged == nil {
+		if failed == nil {
+			writeStatus(w, http.StatusBadGateway, StatusReasonServiceUnavailable, "no backend returned a list")
+			return
+		}
+		if failed.tooLarge {
+			for _, warning := range warnings {
+				w.Header().Add("Warning", warning)
+			}
+			writeStatus(w, http.StatusRequestEntityTooLarge, StatusReasonRequestTooLarge, fmt.Sprintf("lists exceed the limit of %d bytes", failed.limit))
+			return
+		}
+		for k, v := range failed.header {
+			w.Header()[k] = v
+		}
+		w.Header().Del("Content-Length")
+		w.Header()["Warning"] = append(failed.header.Values("Warning"), warnings...)
+		w.WriteHeader(failed.code)
+		_, _ = w.Write(failed.body.Bytes())
+		return
+	}
+
+	body, err := encodeList(merged, kind, items)
+	if err != nil {
+		writeStatus(w, http.StatusBadGateway, StatusReasonServiceUnavailable, fmt.Sprintf("merge lists: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	for _, warning := range warnings {
+		w.Header().Add("Warning", warning)
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// annotate sets the cluster annotation on an item of a list, or on the
+// object of a row of a Table along with the cluster cell.
+func (fo *FanOutRuntime) annotate(item any, kind, backend string) any {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		return item
+	}
+	if kind == "Table" {
+		cells, _ := obj["cells"].([]any)
+		obj["cells"] = append(cells, backend)
+		obj, ok = obj["object"].(map[string]any)
+		if !ok {
+			return item
+		}
+	}
+
+	setMetadata(obj, "annotations", map[string]string{fo.ClusterAnnotation: backend})
+	return item
+}
+
+// decodeList decodes a list or Table, returning its fields, its kind and its
+// items or rows. Numbers are kept as json.Number so that they are encoded
+// unchanged.
+func decodeList(body []byte) (map[string]json.RawMessage, string, []any, error) {
+	var list map[string]json.RawMessage
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, "", nil, err
+	}
+
+	var kind string
+	if err := json.Unmarshal(list["kind"], &kind); err != nil {
+		return nil, "", nil, fmt.Errorf("decode kind: %w", err)
+	}
+	if kind != "Table" && !strings.HasSuffix(kind, "List") {
+		return nil, "", nil, fmt.Errorf("unexpected kind %q", kind)
+	}
+
+	var items []any
+	if raw := list[listField(kind)]; len(raw) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&items); err != nil {
+			return nil, "", nil, fmt.Errorf("decode %s: %w", listField(kind), err)
+		}
+	}
+	return list, kind, items, nil
+}
+
+// encodeList encodes list with items and empty metadata. Tables are given
+// the cluster column.
+func encodeList(list map[string]json.RawMessage, kind string, items []any) ([]byte, error) {
+	if items == nil {
+		items = []any{}
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	list[listField(kind)] = raw
+	list["metadata"] = json.RawMessage("{}")
+
+	if kind == "Table" {
+		var columns []any
+		if err := json.Unmarshal(list["columnDefinitions"], &columns); err != nil {
+			return nil, fmt.Errorf("decode columnDefinitions: %w", err)
+		}
+		if list["columnDefinitions"], err = json.Marshal(append(columns, clusterColumn)); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(list)
+}
+
+func listField(kind string) string {
+	if kind == "Table" {
+		return "rows"
+	}
+	return "items"
+}
+
+// failureMessage returns the message of a Status or plain text response, or
+// its status text.
+func failureMessage(res *bufferedResponse) string {
+	var st Status
+	if err := json.Unmarshal(res.body.Bytes(), &st); err == nil && st.Message != "" {
+		return st.Message
+	}
+	if msg := strings.TrimSpace(res.body.String()); msg != "" && !json.Valid(res.body.Bytes()) && !strings.Contains(msg, "\n") {
+		return msg
+	}
+	return strconv.Itoa(res.code) + " " + http.StatusText(res.code)
+}
+
+// warningHeader formats msg as a Warning header value as sent by the
+// Kubernetes API server.
+func warningHeader(msg string) string {
+	return "299 - " + strconv.Quote(msg)
+}
+
+// bufferedResponse is an http.ResponseWriter that keeps the response in
+// memory. Writes beyond limit fail with an *http.MaxBytesError, stopping the
+// copy of the response, and set tooLarge.
+type bufferedResponse struct {
+	header   http.Header
+	code     int
+	body     bytes.Buffer
+	limit    int64
+	tooLarge bool
+}
+
+func newBufferedResponse(limit int64) *bufferedResponse {
+	return &bufferedResponse{header: http.Header{}, limit: limit}
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	if b.code == 0 {
+		b.code = code
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.code == 0 {
+		b.code = http.StatusOK
+	}
+	if b.tooLarge || int64(b.body.Len())+int64(len(p)) > b.limit {
+		b.tooLarge = true
+		b.body.Reset()
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	return b.body.Write(p)
+}
@@ -0,0 +1,241 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// respond returns a handler answering with code and body.
+func respond(code int, body string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// newFanOut returns a FanOutRuntime of backends named by the keys of
+// handlers, in the given order.
+func newFanOut(t *testing.T, names []string, handlers map[string]http.HandlerFunc) *FanOutRuntime {
+	t.Helper()
+	fo := &FanOutRuntime{ClusterAnnotation: DefaultClusterAnnotation}
+	for _, name := range names {
+		srv := newCountingServer(t, handlers[name])
+		fo.Backends = append(fo.Backends, &FanOutBackend{
+			Name:    name,
+			Handler: NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL)),
+		})
+	}
+	return fo
+}
+
+func podList(names ...string) string {
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, `{"metadata":{"name":"`+name+`","resourceVersion":"12"},"spec":{"priority":2000000000}}`)
+	}
+	return `{"kind":"PodList","apiVersion":"v1","metadata":{"resourceVersion":"12","continue":"abc"},"items":[` + strings.Join(items, ",") + `]}`
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Fan-out
+// ---------------------------------------------------------------------------
+
+func TestFanOut_List(t *testing.T) {
+	var query, accept string
+	fo := newFanOut(t, []string{"a", "b", "c"}, map[string]http.HandlerFunc{
+		"a": func(w http.ResponseWriter, r *http.Request) {
+			query, accept = r.URL.RawQuery, r.Header.Get("Accept")
+			respond(http.StatusOK, podList("a-1", "a-2"))(w, r)
+		},
+		"b": respond(http.StatusOK, podList("b-1")),
+		"c": respond(http.StatusForbidden, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"pods is forbidden","code":403}`),
+	})
+	h := FanOutHandler(fo, http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods?limit=500&labelSelector=app%3Dweb", nil)
+	req.Header.Set("Accept", "application/vnd.kubernetes.protobuf,application/json")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if query != "labelSelector=app%3Dweb" {
+		t.Errorf("expected limit to be removed from the query, got %q", query)
+	}
+	if accept != "application/json" {
+		t.Errorf("expected lists to be requested as JSON, got %q", accept)
+	}
+
+	var list struct {
+		Kind     string         `json:"kind"`
+		Metadata map[string]any `json:"metadata"`
+		Items    []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+			Spec map[string]json.Number `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode merged list: %v", err)
+	}
+	if list.Kind != "PodList" || len(list.Metadata) != 0 {
+		t.Errorf("expected a PodList without metadata, got %s with %v", list.Kind, list.Metadata)
+	}
+
+	var got []string
+	for _, item := range list.Items {
+		got = append(got, item.Metadata.Name+"@"+item.Metadata.Annotations[DefaultClusterAnnotation])
+	}
+	if want := "a-1@a,a-2@a,b-1@b"; strings.Join(got, ",") != want {
+		t.Errorf("expected items %s, got %s", want, strings.Join(got, ","))
+	}
+	if p := list.Items[0].Spec["priority"]; p != "2000000000" {
+		t.Errorf("expected numbers to be kept unchanged, got %s", p)
+	}
+
+	warning := rr.Header().Get("Warning")
+	if !strings.HasPrefix(warning, "299 - ") || !strings.Contains(warning, `backend \"c\" failed: pods is forbidden`) {
+		t.Errorf("expected a warning about backend c, got %q", warning)
+	}
+}
+
+func TestFanOut_Table(t *testing.T) {
+	table := func(name string) string {
+		return `{"kind":"Table","apiVersion":"meta.k8s.io/v1","metadata":{},` +
+			`"columnDefinitions":[{"name":"Name","type":"string","format":"name","description":"","priority":0}],` +
+			`"rows":[{"cells":["` + name + `"],"object":{"kind":"PartialObjectMetadata","metadata":{"name":"` + name + `"}}}]}`
+	}
+	fo := newFanOut(t, []string{"a", "b"}, map[string]http.HandlerFunc{
+		"a": respond(http.StatusOK, table("a-1")),
+		"b": respond(http.StatusOK, table("b-1")),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.Header.Set("Accept", "application/json;as=Table;v=v1;g=meta.k8s.io,application/json")
+	rr := httptest.NewRecorder()
+	FanOutHandler(fo, http.NotFoundHandler()).ServeHTTP(rr, req)
+
+	var got struct {
+		ColumnDefinitions []struct {
+			Name string `json:"name"`
+		} `json:"columnDefinitions"`
+		Rows []struct {
+			Cells  []string `json:"cells"`
+			Object struct {
+				Metadata struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			} `json:"object"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode merged table: %v", err)
+	}
+
+	if len(got.ColumnDefinitions) != 2 || got.ColumnDefinitions[1].Name != "Cluster" {
+		t.Errorf("expected a Cluster column, got %+v", got.ColumnDefinitions)
+	}
+	if len(got.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(got.Rows))
+	}
+	for i, cluster := range []string{"a", "b"} {
+		row := got.Rows[i]
+		if len(row.Cells) != 2 || row.Cells[1] != cluster || row.Object.Metadata.Annotations[DefaultClusterAnnotation] != cluster {
+			t.Errorf("expected row %d to be of cluster %s, got %+v", i, cluster, row)
+		}
+	}
+}
+
+func TestFanOut_AllFailed(t *testing.T) {
+	fo := newFanOut(t, []string{"a", "b"}, map[string]http.HandlerFunc{
+		"a": respond(http.StatusForbidden, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"forbidden","code":403}`),
+		"b": respond(http.StatusOK, `{"kind":"Pod","apiVersion":"v1"}`),
+	})
+
+	rr := httptest.NewRecorder()
+	FanOutHandler(fo, http.NotFoundHandler()).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected the status of the first backend, got %d", rr.Code)
+	}
+	if warnings := rr.Header().Values("Warning"); len(warnings) != 2 {
+		t.Errorf("expected a warning for every backend, got %v", warnings)
+	}
+}
+
+func TestFanOut_MaxListBytes(t *testing.T) {
+	fo := newFanOut(t, []string{"a", "b"}, map[string]http.HandlerFunc{
+		"a": respond(http.StatusOK, podList("a-1", "a-2")),
+		"b": respond(http.StatusOK, podList("b-1")),
+	})
+	fo.MaxListBytes = int64(len(podList("b-1")))
+	h := FanOutHandler(fo, http.NotFoundHandler())
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if strings.Contains(rr.Body.String(), "a-1") || !strings.Contains(rr.Body.String(), "b-1") {
+		t.Errorf("expected only the list of backend b, got %s", rr.Body.String())
+	}
+	if warning := rr.Header().Get("Warning"); !strings.Contains(warning, `backend \"a\" returned a list larger than`) {
+		t.Errorf("expected a warning about backend a, got %q", warning)
+	}
+
+	fo.MaxListBytes = 16
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413 if every list is too large, got %d", rr.Code)
+	}
+	if warnings := rr.Header().Values("Warning"); len(warnings) != 2 {
+		t.Errorf("expected a warning for every backend, got %v", warnings)
+	}
+}
+
+func TestFanOut_NotList(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	fo := newFanOut(t, []string{"a"}, map[string]http.HandlerFunc{"a": respond(http.StatusOK, podList())})
+	next := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL))
+	h := FanOutHandler(fo, next)
+
+	for _, target := range []string{"/api/v1/namespaces/default/pods/web", "/api/v1/pods?watch=true", "/version"} {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+	}
+	if srv.hits.Load() != 3 {
+		t.Errorf("expected requests other than lists to be forwarded to the route backend, got %d", srv.hits.Load())
+	}
+}
+
+func TestJSONAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: "application/json"},
+		{accept: "application/vnd.kubernetes.protobuf", want: "application/json"},
+		{accept: "application/vnd.kubernetes.protobuf, application/json", want: "application/json"},
+		{accept: "application/json;as=Table;v=v1;g=meta.k8s.io,application/json", want: "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"},
+	}
+
+	for _, tt := range tests {
+		if got := jsonAccept(tt.accept); got != tt.want {
+			t.Errorf("jsonAccept(%q): expected %q, got %q", tt.accept, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for flow control.
+const (
+	DefaultQueueLengthLimit = 50
+	DefaultQueueTimeout     = 15 * time.Second
+
+	// CatchAllPriorityLevel is the priority level of requests that match no
+	// other level.
+	CatchAllPriorityLevel = "catch-all"
+)
+
+// Reasons requests are rejected by flow control.
+const (
+	rejectQueueFull        = "queue-full"
+	rejectTimeout          = "time-out"
+	rejectCancelled        = "cancelled"
+	rejectLongRunningLimit = "long-running-limit"
+)
+
+// FlowDistinguisher is what splits the requests of a priority level into
+// flows.
+type FlowDistinguisher int
+
+const (
+	FlowDistinguisherUser FlowDistinguisher = iota
+	FlowDistinguisherNamespace
+)
+
+// FlowControl limits the requests in flight to a backend in the manner of
+// API Priority and Fairness. Short requests are classified into the first of
+// Levels that matches them and take one of its seats, waiting in the queue
+// of their flow while all seats are taken. Long running requests are not
+// classified and are limited by MaxLongRunning instead.
+type FlowControl struct {
+	Backend string
+	// Levels are evaluated in order. Requests matching no level are not
+	// limited.
+	Levels []*PriorityLevel
+	// MaxLongRunning limits long running requests if greater than zero.
+	MaxLongRunning int
+
+	longRunning atomic.Int64
+}
+
+// PriorityLevel is a class of requests with a fixed number of seats. Waiting
+// requests are queued by flow and the flows take turns at free seats, so
+// that a flow with many requests does not starve the others.
+type PriorityLevel struct {
+	Name string
+
+	// Users, Groups and Routes select the requests of the level. The sets
+	// are nil if the level matches any value.
+	Users  map[string]struct{}
+	Groups map[string]struct{}
+	Routes map[string]struct{}
+
+	// Exempt requests are neither queued nor counted.
+	Exempt bool
+	// Seats is the number of requests of the level in flight at a time.
+	Seats         int
+	Distinguisher FlowDistinguisher
+	// QueueLengthLimit is the number of requests of a flow that may wait.
+	QueueLengthLimit int
+	QueueTimeout     time.Duration
+
+	mu       sync.Mutex
+	inFlight int
+	queues   map[string][]*flowWaiter
+	// ring holds the flows with waiting requests in the order they take turns.
+	ring []string
+	next int
+}
+
+type flowWaiter struct {
+	ready    chan struct{}
+	admitted bool
+}
+
+// flowRejection is returned when flow control rejects a request.
+type flowRejection struct {
+	level  string
+	reason string
+}
+
+func (e *flowRejection) Error() string {
+	if e.level == "" {
+		return e.reason
+	}
+	return fmt.Sprintf("priority level %q: %s", e.level, e.reason)
+}
+
+// acquire admits r and returns a function that must be called once the
+// request is done. It waits for a seat if all seats of the priority level of
+// r are taken.
+func (fc *FlowControl) acquire(r *http.Request, route *RouteRuntime) (func(), error) {
+	if isLongRunning(r) {
+		return fc.acquireLongRunning()
+	}
+
+	l := fc.classify(r, route)
+	if l == nil || l.Exempt {
+		return func() {}, nil
+	}
+	return l.acquire(r.Context(), fc.Backend, l.flow(r))
+}
+
+func (fc *FlowControl) acquireLongRunning() (func(), error) {
+	if n := fc.longRunning.Add(1); fc.MaxLongRunning > 0 && n > int64(fc.MaxLongRunning) {
+		fc.longRunning.Add(-1)
+		flowControlRejected.WithLabelValues(fc.Backend, "", rejectLongRunningLimit).Inc()
+		return nil, &flowRejection{reason: rejectLongRunningLimit}
+	}
+	flowControlLongRunning.WithLabelValues(fc.Backend).Inc()
+	return func() {
+		fc.longRunning.Add(-1)
+		flowControlLongRunning.WithLabelValues(fc.Backend).Dec()
+	}, nil
+}
+
+// classify returns the first priority level matching r, or nil if there is
+// none.
+func (fc *FlowControl) classify(r *http.Request, route *RouteRuntime) *PriorityLevel {
+	for _, l := range fc.Levels {
+		if l.matches(r, route) {
+			return l
+		}
+	}
+	return nil
+}
+
+func (l *PriorityLevel) matches(r *http.Request, route *RouteRuntime) bool {
+	if l.Routes != nil {
+		if _, ok := l.Routes[route.Name]; !ok {
+			return false
+		}
+	}
+	if l.Users != nil {
+		if _, ok := l.Users[impersonatedIdentity(r.Context()).Subject]; !ok {
+			return false
+		}
+	}
+	if l.Groups != nil {
+		return slices.ContainsFunc(identityGroups(r.Context()), func(g string) bool {
+			_, ok := l.Groups[g]
+			return ok
+		})
+	}
+	return true
+}
+
+// flow returns the flow of r within the level.
+func (l *PriorityLevel) flow(r *http.Request) string {
+	if l.Distinguisher == FlowDistinguisherNamespace {
+		if info, ok := RequestInfoFromContext(r.Context()); ok {
+			return info.Namespace
+		}
+		return ""
+	}
+	return impersonatedIdentity(r.Context()).Subject
+}
+
+// acquire takes a seat for a request of flow, waiting in the queue of the
+// flow until one is free, QueueTimeout has passed or ctx is done.
+func (l *PriorityLevel) acquire(ctx context.Context, backend, flow string) (func(), error) {
+	l.mu.Lock()
+	// Requests only take a free seat directly if no one is waiting for it.
+	if l.inFlight < l.Seats && len(l.ring) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return l.admit(backend), nil
+	}
+	if len(l.queues[flow]) >= l.QueueLengthLimit {
+		l.mu.Unlock()
+		return nil, l.reject(backend, rejectQueueFull)
+	}
+	w := &flowWaiter{ready: make(chan struct{})}
+	if l.queues == nil {
+		l.queues = map[string][]*flowWaiter{}
+	}
+	if _, ok := l.queues[flow]; !ok {
+		l.ring = append(l.ring, flow)
+	}
+	l.queues[flow] = append(l.queues[flow], w)
+	l.mu.Unlock()
+
+	queued := flowControlQueued.WithLabelValues(backend, l.Name)
+	queued.Inc()
+	defer queued.Dec()
+
+	timer := time.NewTimer(l.QueueTimeout)
+	defer timer.Stop()
+
+	var reason string
+	select {
+	case <-w.ready:
+		return l.admit(backend), nil
+	case <-timer.C:
+		reason = rejectTimeout
+	case <-ctx.Done():
+		reason = rejectCancelled
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The request may have been given a seat while giving up.
+	if w.admitted {
+		return l.admit(backend), nil
+	}
+	l.dequeue(flow, w)
+	return nil, l.reject(backend, reason)
+}
+
+// admit records a request given a seat and returns the function that gives
+// the seat back.
+func (l *PriorityLevel) admit(backend string) func() {
+	inFlight := flowControlInFlight.WithLabelValues(backend, l.Name)
+	inFlight.Inc()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			inFlight.Dec()
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inFlight--
+			l.dispatch()
+		})
+	}
+}
+
+func (l *PriorityLevel) reject(backend, reason string) error {
+	flowControlRejected.WithLabelValues(backend, l.Name, reason).Inc()
+	return &flowRejection{level: l.Name, reason: reason}
+}
+
+// dispatch gives free seats to waiting requests, one flow at a time. l.mu
+// must be held.
+func (l *PriorityLevel) dispatch() {
+	for l.inFlight < l.Seats && len(l.ring) > 0 {
+		l.next %= len(l.ring)
+		flow := l.ring[l.next]
+		q := l.queues[flow]
+
+		w := q[0]
+		if len(q) == 1 {
+			delete(l.queues, flow)
+			l.ring = slices.Delete(l.ring, l.next, l.next+1)
+		} else {
+			l.queues[flow] = q[1:]
+			l.next++
+		}
+
+		w.admitted = true
+		l.inFlight++
+		close(w.ready)
+	}
+}
+
+// dequeue removes w from the queue of flow. l.mu must be held.
+func (l *PriorityLevel) dequeue(flow string, w *flowWaiter) {
+	q := slices.DeleteFunc(l.queues[flow], func(o *flowWaiter) bool { return o == w })
+	if len(q) > 0 {
+		l.queues[flow] = q
+		return
+	}
+
+	delete(l.queues, flow)
+	i := slices.Index(l.ring, flow)
+	l.ring = slices.Delete(l.ring, i, i+1)
+	if i < l.next {
+		l.next--
+	}
+}
+
+// writeFlowControlRejected writes a 429 Status response for a request
+// rejected by the flow control of backend.
+func writeFlowControlRejected(w http.ResponseWriter, backend string, err error) {
+	w.Header().Set("Retry-After", "1")
+	writeStatus(w, http.StatusTooManyRequests, StatusReasonTooManyRequests, fmt.Sprintf("too many requests to backend %q, %v", backend, err))
+}
@@ -0,0 +1,239 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+type admission struct {
+	flow    string
+	release func()
+	err     error
+}
+
+// queuedRequests returns the number of requests waiting in l.
+func queuedRequests(l *PriorityLevel) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, q := range l.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// enqueue starts a request of flow that is expected to wait in l, and
+// returns once it is queued. The admission is sent on admitted.
+func enqueue(t *testing.T, l *PriorityLevel, flow string, admitted chan<- admission) {
+	t.Helper()
+	n := queuedRequests(l)
+	go func() {
+		release, err := l.acquire(context.Background(), "be", flow)
+		admitted <- admission{flow: flow, release: release, err: err}
+	}()
+	deadline := time.Now().Add(time.Second)
+	for queuedRequests(l) != n+1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a request of flow %s to be queued", flow)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func rejectReason(err error) string {
+	var rejection *flowRejection
+	if errors.As(err, &rejection) {
+		return rejection.reason
+	}
+	return ""
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Flow control
+// ---------------------------------------------------------------------------
+
+func TestPriorityLevel_FairQueuing(t *testing.T) {
+	l := &PriorityLevel{Name: "l", Seats: 1, QueueLengthLimit: 10, QueueTimeout: time.Minute}
+	release, err := l.acquire(context.Background(), "be", "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	admitted := make(chan admission)
+	for _, flow := range []string{"a", "a", "a", "b"} {
+		enqueue(t, l, flow, admitted)
+	}
+
+	var order []string
+	for range 4 {
+		release()
+		a := <-admitted
+		if a.err != nil {
+			t.Fatalf("unexpected error: %v", a.err)
+		}
+		order = append(order, a.flow)
+		release = a.release
+	}
+	release()
+
+	if got := strings.Join(order, ","); got != "a,b,a,a" {
+		t.Errorf("expected flows to take turns, got order %s", got)
+	}
+	if l.inFlight != 0 {
+		t.Errorf("expected all seats to be given back, got %d in flight", l.inFlight)
+	}
+}
+
+func TestPriorityLevel_Reject(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name  string
+		level *PriorityLevel
+		ctx   context.Context
+		want  string
+	}{
+		{name: "queue full", level: &PriorityLevel{Name: "l", Seats: 1, QueueTimeout: time.Minute}, ctx: context.Background(), want: rejectQueueFull},
+		{name: "timeout", level: &PriorityLevel{Name: "l", Seats: 1, QueueLengthLimit: 1, QueueTimeout: 10 * time.Millisecond}, ctx: context.Background(), want: rejectTimeout},
+		{name: "cancelled", level: &PriorityLevel{Name: "l", Seats: 1, QueueLengthLimit: 1, QueueTimeout: time.Minute}, ctx: cancelled, want: rejectCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := tt.level.acquire(context.Background(), "be", "a")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer release()
+
+			_, err = tt.level.acquire(tt.ctx, "be", "a")
+			if got := rejectReason(err); got != tt.want {
+				t.Fatalf("expected rejection %q, got %v", tt.want, err)
+			}
+			if n := queuedRequests(tt.level); n != 0 || len(tt.level.ring) != 0 {
+				t.Errorf("expected the rejected request to leave the queue, got %d queued", n)
+			}
+		})
+	}
+}
+
+func TestFlowControl_Classify(t *testing.T) {
+	fc := &FlowControl{Levels: []*PriorityLevel{
+		{Name: "exempt", Groups: map[string]struct{}{"system:masters": {}}, Exempt: true},
+		{Name: "ci", Users: map[string]struct{}{"ci": {}}, Routes: map[string]struct{}{"r": {}}},
+		{Name: "anonymous", Groups: map[string]struct{}{UnauthenticatedGroup: {}}},
+		{Name: CatchAllPriorityLevel},
+	}}
+	route := &RouteRuntime{Name: "r"}
+
+	tests := []struct {
+		name  string
+		id    *Identity
+		route *RouteRuntime
+		want  string
+	}{
+		{name: "group", id: &Identity{Subject: "admin", Groups: []string{"dev", "system:masters"}}, route: route, want: "exempt"},
+		{name: "user and route", id: &Identity{Subject: "ci"}, route: route, want: "ci"},
+		{name: "user on other route", id: &Identity{Subject: "ci"}, route: &RouteRuntime{Name: "other"}, want: CatchAllPriorityLevel},
+		{name: "unauthenticated", route: route, want: "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := fc.classify(requestAs(tt.id, "10.0.0.1:1234"), tt.route)
+			if l == nil || l.Name != tt.want {
+				t.Errorf("expected priority level %s, got %v", tt.want, l)
+			}
+		})
+	}
+}
+
+func TestPriorityLevel_FlowDistinguisher(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/dev/pods", nil)
+	req = req.WithContext(WithIdentity(req.Context(), &Identity{Subject: "alice"}))
+	req = req.WithContext(WithRequestInfo(req.Context(), NewRequestInfo(req)))
+
+	if got := (&PriorityLevel{Distinguisher: FlowDistinguisherUser}).flow(req); got != "alice" {
+		t.Errorf("expected flow alice, got %q", got)
+	}
+	if got := (&PriorityLevel{Distinguisher: FlowDistinguisherNamespace}).flow(req); got != "dev" {
+		t.Errorf("expected flow dev, got %q", got)
+	}
+}
+
+func TestFlowControl_LongRunning(t *testing.T) {
+	fc := &FlowControl{
+		Backend:        "be",
+		Levels:         []*PriorityLevel{{Name: CatchAllPriorityLevel, Seats: 1}},
+		MaxLongRunning: 1,
+	}
+	route := &RouteRuntime{Name: "r"}
+	watch := httptest.NewRequest(http.MethodGet, "/api/v1/pods?watch=true", nil)
+
+	release, err := fc.acquire(watch, route)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fc.acquire(watch, route); rejectReason(err) != rejectLongRunningLimit {
+		t.Fatalf("expected rejection %q, got %v", rejectLongRunningLimit, err)
+	}
+
+	// Watches do not take the seats of short requests.
+	short, err := fc.acquire(httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil), route)
+	if err != nil {
+		t.Fatalf("expected short requests to be admitted, got %v", err)
+	}
+	short()
+
+	release()
+	release, err = fc.acquire(watch, route)
+	if err != nil {
+		t.Fatalf("expected a watch to be admitted once another ended, got %v", err)
+	}
+	release()
+}
+
+func TestProxy_FlowControl(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	rr := &RouteRuntime{Name: "r"}
+	p := newRouteProxy(t, srv.URL, rr)
+	level := &PriorityLevel{Name: CatchAllPriorityLevel, Seats: 1, QueueTimeout: time.Minute}
+	rr.BackendPool.Backend.FlowControl = &FlowControl{Backend: "be", Levels: []*PriorityLevel{level}}
+
+	release, err := level.acquire(context.Background(), "be", AnonymousUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), rejectQueueFull) {
+		t.Errorf("expected the rejection reason in the Status, got %q", rec.Body.String())
+	}
+
+	release()
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 once the seat was given back, got %d", rec.Code)
+	}
+	if level.inFlight != 0 {
+		t.Errorf("expected the seat to be given back after the request, got %d in flight", level.inFlight)
+	}
+}
@@ -3,10 +3,19 @@ package proxy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+)
+
+var (
+	errNoHealthyUpstream = errors.New("no healthy upstream")
+	errCredentials       = errors.New("apply credentials")
 )
 
 type Forwarder struct {
@@ -17,32 +26,145 @@ func NewForwarder(transport http.RoundTripper) *Forwarder {
 	return &Forwarder{transport: transport}
 }
 
-func (f *Forwarder) Handler(pool *BackendPool) http.Handler {
+type handlerConfig struct {
+	retry             *RetryPolicy
+	streamIdleTimeout time.Duration
+	rewrite           *RewriteRuntime
+	requestHeaders    *HeaderMutationRuntime
+	responseHeaders   *HeaderMutationRuntime
+}
+
+type HandlerOption func(*handlerConfig)
+
+// WithRetryPolicy retries requests according to rp.
+func WithRetryPolicy(rp *RetryPolicy) HandlerOption {
+	return func(c *handlerConfig) {
+		c.retry = rp
+	}
+}
+
+// WithStreamIdleTimeout closes upgraded connections, watches and log follows
+// that have been idle for d. A zero d disables the idle timeout.
+func WithStreamIdleTimeout(d time.Duration) HandlerOption {
+	return func(c *handlerConfig) {
+		c.streamIdleTimeout = d
+	}
+}
+
+func (f *Forwarder) Handler(pool *BackendPool, opts ...HandlerOption) http.Handler {
+	cfg := &handlerConfig{
+		streamIdleTimeout: DefaultStreamIdleTimeout,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		target, ok := pool.Next(r)
-		if !ok {
-			http.Error(w, "no healthy upstream", http.StatusBadGateway)
+		r = cfg.rewrite.apply(r)
+
+		// Cached responses are served without involving the backend.
+		var key string
+		c := pool.responseCache()
+		if c != nil && isCacheable(r) {
+			key = cacheKey(r)
+			if !noCache(r) && serveFromCache(w, r, pool.Name, c, key) {
+				return
+			}
+		}
+
+		cb := pool.circuitBreaker()
+		if cb != nil {
+			if ok, retryAfter := cb.Allow(time.Now()); !ok {
+				circuitBreakerRejected.WithLabelValues(pool.Name).Inc()
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				writeStatus(w, http.StatusServiceUnavailable, StatusReasonServiceUnavailable, fmt.Sprintf("circuit breaker for backend %q is open", pool.Name))
+				return
+			}
+		}
+
+		if isUpgradeRequest(r) {
+			f.serveUpgrade(w, r, pool, cb, cfg)
 			return
 		}
 
-		outReq := cloneRequestForTarget(r, target)
-		resp, err := f.transport.RoundTrip(outReq)
+		decoration := pool.decoration()
+		r = decoration.prepare(r)
+
+		resp, cancel, err := f.roundTrip(r, pool, cfg)
+		if cb != nil {
+			recordCircuit(r, cb, resp, err)
+		}
 		if err != nil {
+			if errors.Is(err, errNoHealthyUpstream) {
+				http.Error(w, "no healthy upstream", http.StatusBadGateway)
+				return
+			}
 			writeProxyError(w, err)
 			return
 		}
 		defer func() {
 			_ = resp.Body.Close()
+			cancel()
 		}()
 
+		pool.mutateResponseHeaders(r, resp, cfg)
+		decoration.decorate(r, resp)
+
+		if key != "" && resp.StatusCode == http.StatusOK {
+			writeAndCache(w, resp, pool.Name, c, key)
+			return
+		}
+
 		copyHeader(w.Header(), resp.Header)
 		w.WriteHeader(resp.StatusCode)
 
+		if isLongRunning(r) && cfg.streamIdleTimeout > 0 {
+			// Cancelling the request unblocks the copy below.
+			idle := time.AfterFunc(cfg.streamIdleTimeout, cancel)
+			defer idle.Stop()
+			_, _ = io.Copy(idleWriter{w: flushWriter{ResponseWriter: w}, timer: idle, timeout: cfg.streamIdleTimeout}, resp.Body)
+			return
+		}
+
 		_, _ = io.Copy(flushWriter{ResponseWriter: w}, resp.Body)
 	})
 }
 
-func cloneRequestForTarget(in *http.Request, target *BackendRuntime) *http.Request {
+// failedRoundTrip returns true if the outcome of a round trip counts as a
+// failure of the backend. Connection failures, timeouts and 5xx responses are
+// failures.
+func failedRoundTrip(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// notBackendFault returns true if a round trip failed because the client went
+// away, sent a body that is too large or because the credentials of the
+// backend could not be applied, which says nothing about the backend.
+func notBackendFault(r *http.Request, err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return err != nil && (errors.Is(r.Context().Err(), context.Canceled) || errors.As(err, &maxBytesErr) || errors.Is(err, errCredentials))
+}
+
+// observeTarget feeds the outcome of a single attempt to outlier detection.
+func observeTarget(r *http.Request, pool *BackendPool, target *BackendTarget, resp *http.Response, err error) {
+	if pool.Backend == nil || notBackendFault(r, err) {
+		return
+	}
+	target.observe(pool.Backend.OutlierDetection, failedRoundTrip(resp, err), time.Now())
+}
+
+// recordCircuit feeds the final outcome of a request to the circuit breaker.
+func recordCircuit(r *http.Request, cb *CircuitBreaker, resp *http.Response, err error) {
+	if notBackendFault(r, err) {
+		cb.Abort()
+		return
+	}
+	cb.Record(failedRoundTrip(resp, err), time.Now())
+}
+
+// cloneRequestForTarget returns a copy of in addressed to target, with headers
+// set by copyForwardingHeaders and credentials of the backend applied.
+func cloneRequestForTarget(in *http.Request, target *BackendTarget, routeHeaders *HeaderMutationRuntime) (*http.Request, error) {
 	out := in.Clone(in.Context())
 	out.URL.Scheme = target.URL.Scheme
 	out.URL.Host = target.URL.Host
@@ -56,13 +178,40 @@ func cloneRequestForTarget(in *http.Request, target *BackendRuntime) *http.Reque
 	out.Host = target.URL.Host
 	out.RequestURI = ""
 
-	copyForwardingHeaders(out, in)
-	return out
+	copyForwardingHeaders(out, in, target.Backend, routeHeaders)
+
+	if target.Backend != nil && target.Backend.AuthInjector != nil {
+		if err := target.Backend.AuthInjector.Apply(out); err != nil {
+			return nil, fmt.Errorf("%w of backend %q: %w", errCredentials, target.Backend.Name, err)
+		}
+	}
+	return out, nil
 }
 
-func copyForwardingHeaders(out, in *http.Request) {
+// copyForwardingHeaders copies the headers of in to out. The identity of the
+// client is impersonated if the backend says so, and the Impersonate-* headers
+// of the client are removed from requests sent with the credentials of the
+// backend. The request header mutations of the backend and then routeHeaders
+// are applied last.
+func copyForwardingHeaders(out, in *http.Request, backend *BackendRuntime, routeHeaders *HeaderMutationRuntime) {
 	out.Header = in.Header.Clone()
 
+	var name string
+	var backendHeaders *HeaderMutationRuntime
+	if backend != nil {
+		name = backend.Name
+		backendHeaders = backend.RequestHeaders
+		switch {
+		case backend.Impersonation == ImpersonationIdentity:
+			impersonate(out.Header, impersonatedIdentity(in.Context()))
+		case backend.AuthInjector != nil:
+			// The client must not impersonate anyone with the credentials of
+			// the backend.
+			removeImpersonation(out.Header)
+		}
+	}
+	applyHeaderMutations(out.Header, newHeaderTemplateData(in.Context(), name), backendHeaders, routeHeaders)
+
 	remoteIP := clientIPFromRequest(in)
 	appendHeader(out.Header, "X-Forwarded-For", remoteIP)
 	out.Header.Set("X-Forwarded-Host", in.Host)
@@ -100,6 +249,11 @@ func copyHeader(dst, src http.Header) {
 }
 
 func writeProxyError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeRequestTooLarge(w, maxBytesErr.Limit)
+		return
+	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		http.Error(w, "upstream timeout", http.StatusGatewayTimeout)
 		return
@@ -118,3 +272,19 @@ func (fw flushWriter) Write(p []byte) (int, error) {
 	}
 	return n, err
 }
+
+// idleWriter resets timer to timeout on every write.
+type idleWriter struct {
+	w       io.Writer
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (iw idleWriter) Write(p []byte) (int, error) {
+	iw.timer.Reset(iw.timeout)
+	return iw.w.Write(p)
+}
+
+func writeRequestTooLarge(w http.ResponseWriter, limit int64) {
+	writeStatus(w, http.StatusRequestEntityTooLarge, StatusReasonRequestTooLarge, fmt.Sprintf("request body exceeds the limit of %d bytes", limit))
+}
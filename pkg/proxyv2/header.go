@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// HeaderMutationRuntime is a compiled set of header changes. Remove is applied
+// first, then Set and finally Add.
+type HeaderMutationRuntime struct {
+	Add    []HeaderValueRuntime
+	Set    []HeaderValueRuntime
+	Remove []string
+}
+
+// HeaderValueRuntime is a header name along with its value template.
+type HeaderValueRuntime struct {
+	Name  string
+	Value *template.Template
+}
+
+// headerTemplateData is what header value templates are executed with.
+type headerTemplateData struct {
+	Subject string
+	Claims  map[string]string
+	Backend string
+}
+
+// NewHeaderValue parses value as a template for the header name.
+func NewHeaderValue(name, value string) (HeaderValueRuntime, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(value)
+	if err != nil {
+		return HeaderValueRuntime{}, err
+	}
+	return HeaderValueRuntime{Name: http.CanonicalHeaderKey(name), Value: tmpl}, nil
+}
+
+// WithHeaderMutations changes the headers of requests before they are
+// forwarded and of responses before they are returned. They are applied after
+// those of the backend.
+func WithHeaderMutations(request, response *HeaderMutationRuntime) HandlerOption {
+	return func(c *handlerConfig) {
+		c.requestHeaders = request
+		c.responseHeaders = response
+	}
+}
+
+func newHeaderTemplateData(ctx context.Context, backend string) *headerTemplateData {
+	data := &headerTemplateData{Backend: backend}
+	if id, ok := IdentityFromContext(ctx); ok {
+		data.Subject = id.Subject
+		data.Claims = id.Claims
+	}
+	return data
+}
+
+// mutateResponseHeaders applies the response header mutations of the backend
+// and then those of the route to resp.
+func (p *BackendPool) mutateResponseHeaders(r *http.Request, resp *http.Response, cfg *handlerConfig) {
+	var backendHeaders *HeaderMutationRuntime
+	if p.Backend != nil {
+		backendHeaders = p.Backend.ResponseHeaders
+	}
+	if backendHeaders == nil && cfg.responseHeaders == nil {
+		return
+	}
+	applyHeaderMutations(resp.Header, newHeaderTemplateData(r.Context(), p.Name), backendHeaders, cfg.responseHeaders)
+}
+
+// applyHeaderMutations applies each non-nil mutation to h in order.
+func applyHeaderMutations(h http.Header, data *headerTemplateData, mutations ...*HeaderMutationRuntime) {
+	for _, m := range mutations {
+		if m == nil {
+			continue
+		}
+		for _, name := range m.Remove {
+			h.Del(name)
+		}
+		for _, hv := range m.Set {
+			if v, ok := hv.render(data); ok {
+				h.Set(hv.Name, v)
+			}
+		}
+		for _, hv := range m.Add {
+			if v, ok := hv.render(data); ok {
+				h.Add(hv.Name, v)
+			}
+		}
+	}
+}
+
+// render executes the value template. Values that fail to render or that
+// contain line breaks, which could be used to inject headers, are dropped.
+func (hv HeaderValueRuntime) render(data *headerTemplateData) (string, bool) {
+	var b strings.Builder
+	if err := hv.Value.Execute(&b, data); err != nil {
+		return "", false
+	}
+	v := b.String()
+	if strings.ContainsAny(v, "\r\n") {
+		return "", false
+	}
+	return v, true
+}
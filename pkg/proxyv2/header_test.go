@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func mustHeaderValue(t *testing.T, name, value string) HeaderValueRuntime {
+	t.Helper()
+	hv, err := NewHeaderValue(name, value)
+	if err != nil {
+		t.Fatalf("parse header value %q: %v", value, err)
+	}
+	return hv
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Header mutations
+// ---------------------------------------------------------------------------
+
+func TestForwarder_HeaderMutations(t *testing.T) {
+	var got http.Header
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Server", "kube-apiserver")
+	})
+
+	pool := newRetryPool(t, srv.URL)
+	pool.Backend.RequestHeaders = &HeaderMutationRuntime{
+		Set: []HeaderValueRuntime{mustHeaderValue(t, "X-Gateway-Key", "secret")},
+	}
+	pool.Backend.ResponseHeaders = &HeaderMutationRuntime{
+		Set: []HeaderValueRuntime{mustHeaderValue(t, "X-Multikube-Cluster", "{{ .Backend }}")},
+	}
+
+	routeReq := &HeaderMutationRuntime{
+		Remove: []string{"Authorization"},
+		Set:    []HeaderValueRuntime{mustHeaderValue(t, "X-Remote-User", "{{ .Subject }}")},
+		Add:    []HeaderValueRuntime{mustHeaderValue(t, "X-Remote-Group", `{{ index .Claims "group" }}`)},
+	}
+	routeResp := &HeaderMutationRuntime{Remove: []string{"Server"}}
+
+	h := NewForwarder(http.DefaultTransport).Handler(pool, WithHeaderMutations(routeReq, routeResp))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.Header.Set("Authorization", "Bearer client-token")
+	req.Header.Set("X-Remote-User", "spoofed")
+	req = req.WithContext(WithIdentity(context.Background(), &Identity{Subject: "alice", Claims: map[string]string{"group": "admins"}}))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if got.Get("Authorization") != "" {
+		t.Errorf("expected Authorization to be removed, got %q", got.Get("Authorization"))
+	}
+	if got.Get("X-Remote-User") != "alice" {
+		t.Errorf("expected X-Remote-User alice, got %q", got.Values("X-Remote-User"))
+	}
+	if got.Get("X-Remote-Group") != "admins" {
+		t.Errorf("expected X-Remote-Group admins, got %q", got.Get("X-Remote-Group"))
+	}
+	if got.Get("X-Gateway-Key") != "secret" {
+		t.Errorf("expected backend header to be set, got %q", got.Get("X-Gateway-Key"))
+	}
+	if rr.Header().Get("X-Multikube-Cluster") != "be" {
+		t.Errorf("expected X-Multikube-Cluster be, got %q", rr.Header().Get("X-Multikube-Cluster"))
+	}
+	if rr.Header().Get("Server") != "" {
+		t.Errorf("expected Server to be removed, got %q", rr.Header().Get("Server"))
+	}
+}
+
+func TestApplyHeaderMutations_RouteAfterBackend(t *testing.T) {
+	backend := &HeaderMutationRuntime{Set: []HeaderValueRuntime{mustHeaderValue(t, "X-Tier", "backend")}}
+	route := &HeaderMutationRuntime{Set: []HeaderValueRuntime{mustHeaderValue(t, "X-Tier", "route")}}
+
+	h := http.Header{}
+	applyHeaderMutations(h, &headerTemplateData{}, backend, nil, route)
+
+	if h.Get("X-Tier") != "route" {
+		t.Errorf("expected route mutation to win, got %q", h.Get("X-Tier"))
+	}
+}
+
+func TestApplyHeaderMutations_DropsLineBreaks(t *testing.T) {
+	m := &HeaderMutationRuntime{Set: []HeaderValueRuntime{mustHeaderValue(t, "X-Remote-User", "{{ .Subject }}")}}
+
+	h := http.Header{}
+	applyHeaderMutations(h, &headerTemplateData{Subject: "alice\r\nX-Injected: 1"}, m)
+
+	if _, ok := h["X-Remote-User"]; ok {
+		t.Errorf("expected value with line breaks to be dropped, got %q", h.Get("X-Remote-User"))
+	}
+}
@@ -0,0 +1,354 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/amimof/multikube/pkg/logger"
+)
+
+// Defaults for health checks. They match the defaults of Kubernetes probes.
+const (
+	DefaultHealthCheckPath     = "/readyz"
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultHealthCheckTimeout  = time.Second
+	DefaultHealthyThreshold    = 1
+	DefaultUnhealthyThreshold  = 3
+)
+
+// HealthCheckRuntime is the compiled health check configuration of a backend.
+type HealthCheckRuntime struct {
+	Path               string
+	Interval           time.Duration
+	Timeout            time.Duration
+	HealthyThreshold   int
+	UnhealthyThreshold int
+}
+
+// TargetHealth is the last observed health of a single backend target.
+type TargetHealth struct {
+	ID        string
+	Healthy   bool
+	LastProbe time.Time
+	// Message holds the reason of the last failed probe.
+	Message string
+
+	Ejected      bool
+	EjectedUntil time.Time
+}
+
+// BackendHealth is the observed health of a backend and all of its targets.
+type BackendHealth struct {
+	Targets []TargetHealth
+	Circuit CircuitState
+}
+
+// Available returns true if at least one target is healthy and not ejected.
+func (b BackendHealth) Available() bool {
+	for _, t := range b.Targets {
+		if t.Healthy && !t.Ejected {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares the state of two BackendHealths, ignoring probe times and
+// messages so that only transitions are reported.
+func (b BackendHealth) equal(o BackendHealth) bool {
+	if b.Circuit != o.Circuit || len(b.Targets) != len(o.Targets) {
+		return false
+	}
+	for i := range b.Targets {
+		x, y := b.Targets[i], o.Targets[i]
+		if x.ID != y.ID || x.Healthy != y.Healthy || x.Ejected != y.Ejected {
+			return false
+		}
+	}
+	return true
+}
+
+// HealthStatusFunc is called with the health of a backend whenever the health,
+// ejection or circuit state of the backend or one of its targets changes.
+type HealthStatusFunc func(ctx context.Context, backend string, health BackendHealth)
+
+type HealthCheckerOption func(*HealthChecker)
+
+func WithHealthLogger(l logger.Logger) HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.logger = l
+	}
+}
+
+func WithHealthStatusFunc(fn HealthStatusFunc) HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.onStatus = fn
+	}
+}
+
+// HealthChecker periodically probes the targets of every backend in the
+// current runtime that has a health check configured. Health is flipped
+// directly on the runtime targets so no recompile is needed. State is kept
+// across runtime snapshots and re-applied to the targets of new snapshots.
+//
+// The HealthChecker also reports the state of passive outlier detection and
+// circuit breakers, which are driven by the Forwarder.
+type HealthChecker struct {
+	runtime    *RuntimeStore
+	logger     logger.Logger
+	onStatus   HealthStatusFunc
+	resolution time.Duration
+	states     map[targetKey]*targetState
+	reported   map[string]BackendHealth
+}
+
+type targetKey struct {
+	backend string
+	target  string
+}
+
+type targetState struct {
+	TargetHealth
+	successes int
+	failures  int
+}
+
+func NewHealthChecker(rs *RuntimeStore, opts ...HealthCheckerOption) *HealthChecker {
+	h := &HealthChecker{
+		runtime:    rs,
+		logger:     logger.ConsoleLogger{},
+		onStatus:   func(context.Context, string, BackendHealth) {},
+		resolution: time.Second,
+		states:     make(map[targetKey]*targetState),
+		reported:   make(map[string]BackendHealth),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Run probes targets until ctx is cancelled.
+func (h *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.resolution)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.check(ctx, time.Now())
+		}
+	}
+}
+
+type probeJob struct {
+	key     targetKey
+	backend *BackendRuntime
+	target  *BackendTarget
+	err     error
+}
+
+// check probes every target that is due at now and waits for the probes to
+// complete. It is not safe for concurrent use.
+func (h *HealthChecker) check(ctx context.Context, now time.Time) {
+	rt := h.runtime.Load()
+	if rt == nil {
+		return
+	}
+
+	seen := make(map[targetKey]struct{})
+	var jobs []*probeJob
+
+	for _, br := range rt.Backends {
+		if br.HealthCheck == nil {
+			continue
+		}
+		for _, t := range br.Targets {
+			key := targetKey{backend: br.Name, target: t.ID}
+			seen[key] = struct{}{}
+
+			st, ok := h.states[key]
+			if !ok {
+				st = &targetState{TargetHealth: TargetHealth{ID: t.ID, Healthy: t.Healthy.Load()}}
+				h.states[key] = st
+			}
+
+			// Targets of a freshly compiled runtime start out with their compiled
+			// health, so re-apply what we know.
+			t.Healthy.Store(st.Healthy)
+
+			if st.LastProbe.IsZero() || now.Sub(st.LastProbe) >= br.HealthCheck.Interval {
+				jobs = append(jobs, &probeJob{key: key, backend: br, target: t})
+			}
+		}
+	}
+
+	for key := range h.states {
+		if _, ok := seen[key]; !ok {
+			delete(h.states, key)
+			backendTargetHealthy.DeleteLabelValues(key.backend, key.target)
+			backendTargetEjected.DeleteLabelValues(key.backend, key.target)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.err = probe(ctx, job.backend, job.target)
+		}()
+	}
+	wg.Wait()
+
+	for _, job := range jobs {
+		h.record(job, now)
+	}
+
+	h.report(ctx, rt, now)
+}
+
+// report calls the HealthStatusFunc for every backend whose health changed
+// since it was last reported. Backends without health checks, outlier
+// detection or circuit breaker are never reported.
+func (h *HealthChecker) report(ctx context.Context, rt *RuntimeConfig, now time.Time) {
+	for name := range h.reported {
+		if _, ok := rt.Backends[name]; !ok {
+			delete(h.reported, name)
+			circuitBreakerState.DeleteLabelValues(name)
+			backendTargetEjected.DeletePartialMatch(prometheus.Labels{"backend": name})
+		}
+	}
+
+	for name, br := range rt.Backends {
+		if br.HealthCheck == nil && br.OutlierDetection == nil && br.CircuitBreaker == nil {
+			continue
+		}
+
+		health := h.snapshot(br, now)
+		if prev, ok := h.reported[name]; ok && prev.equal(health) {
+			continue
+		}
+		h.reported[name] = health
+		h.onStatus(ctx, name, health)
+	}
+}
+
+// snapshot returns the current health of a backend and updates the ejection
+// and circuit breaker gauges.
+func (h *HealthChecker) snapshot(br *BackendRuntime, now time.Time) BackendHealth {
+	health := BackendHealth{
+		Targets: make([]TargetHealth, 0, len(br.Targets)),
+		Circuit: CircuitClosed,
+	}
+
+	if br.CircuitBreaker != nil {
+		health.Circuit = br.CircuitBreaker.State(now)
+		circuitBreakerState.WithLabelValues(br.Name).Set(float64(health.Circuit))
+	}
+
+	for _, t := range br.Targets {
+		th := TargetHealth{ID: t.ID, Healthy: t.Healthy.Load()}
+		if st, ok := h.states[targetKey{backend: br.Name, target: t.ID}]; ok {
+			th.LastProbe = st.LastProbe
+			th.Message = st.Message
+		}
+		th.EjectedUntil, th.Ejected = t.Ejected(now)
+		if br.OutlierDetection != nil {
+			backendTargetEjected.WithLabelValues(br.Name, t.ID).Set(boolToFloat(th.Ejected))
+		}
+		health.Targets = append(health.Targets, th)
+	}
+
+	return health
+}
+
+// record applies the result of a probe to the target state and flips the
+// health of the target once a threshold is reached.
+func (h *HealthChecker) record(job *probeJob, now time.Time) {
+	st := h.states[job.key]
+	hc := job.backend.HealthCheck
+	prev := st.Healthy
+
+	st.LastProbe = now
+	if job.err != nil {
+		healthChecks.WithLabelValues(job.key.backend, "failure").Inc()
+		st.Message = job.err.Error()
+		st.successes = 0
+		st.failures++
+		if st.failures >= hc.UnhealthyThreshold {
+			st.Healthy = false
+		}
+	} else {
+		healthChecks.WithLabelValues(job.key.backend, "success").Inc()
+		st.Message = ""
+		st.failures = 0
+		st.successes++
+		if st.successes >= hc.HealthyThreshold {
+			st.Healthy = true
+		}
+	}
+
+	job.target.Healthy.Store(st.Healthy)
+	backendTargetHealthy.WithLabelValues(job.key.backend, job.key.target).Set(boolToFloat(st.Healthy))
+
+	if prev != st.Healthy {
+		h.logger.Info("backend target health changed", "backend", job.key.backend, "target", job.key.target, "healthy", st.Healthy, "reason", st.Message)
+	}
+}
+
+// probe performs a single health check request against a target using the
+// transport of its backend. Any 2xx response is considered healthy.
+func probe(ctx context.Context, br *BackendRuntime, t *BackendTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, br.HealthCheck.Timeout)
+	defer cancel()
+
+	u := *t.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + br.HealthCheck.Path
+	u.RawPath = ""
+	u.RawQuery = ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if br.AuthInjector != nil {
+		if err := br.AuthInjector.Apply(req); err != nil {
+			return fmt.Errorf("applying credentials: %w", err)
+		}
+	}
+
+	transport := br.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,139 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amimof/multikube/pkg/logger"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func newHealthCheckedRuntime(t *testing.T, server string) (*RuntimeStore, *BackendTarget) {
+	t.Helper()
+	u, err := url.Parse(server)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	br := &BackendRuntime{
+		Name: "be",
+		HealthCheck: &HealthCheckRuntime{
+			Path:               DefaultHealthCheckPath,
+			Interval:           time.Second,
+			Timeout:            time.Second,
+			HealthyThreshold:   1,
+			UnhealthyThreshold: 2,
+		},
+		Transport: http.DefaultTransport,
+	}
+	target := &BackendTarget{ID: u.String(), URL: u, Backend: br}
+	target.Healthy.Store(true)
+	br.Targets = []*BackendTarget{target}
+
+	store := NewRuntimeStore()
+	store.Store(&RuntimeConfig{Backends: map[string]*BackendRuntime{"be": br}})
+	return store, target
+}
+
+// ---------------------------------------------------------------------------
+// Tests — HealthChecker
+// ---------------------------------------------------------------------------
+
+func TestHealthChecker_Thresholds(t *testing.T) {
+	var ready atomic.Bool
+	ready.Store(true)
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	store, target := newHealthCheckedRuntime(t, srv.URL)
+
+	var reports [][]TargetHealth
+	h := NewHealthChecker(store,
+		WithHealthLogger(logger.NilLogger{}),
+		WithHealthStatusFunc(func(_ context.Context, backend string, health BackendHealth) {
+			reports = append(reports, health.Targets)
+		}),
+	)
+
+	now := time.Now()
+	ctx := context.Background()
+
+	// First probe is always reported
+	h.check(ctx, now)
+	if gotPath != DefaultHealthCheckPath {
+		t.Errorf("expected probe path %q, got %q", DefaultHealthCheckPath, gotPath)
+	}
+	if len(reports) != 1 || !reports[0][0].Healthy {
+		t.Fatalf("expected one healthy report, got %+v", reports)
+	}
+
+	// Not due yet
+	ready.Store(false)
+	h.check(ctx, now.Add(500*time.Millisecond))
+	if !target.Healthy.Load() {
+		t.Fatal("expected target to still be healthy before interval elapsed")
+	}
+
+	// One failure is below the unhealthy threshold
+	h.check(ctx, now.Add(time.Second))
+	if !target.Healthy.Load() {
+		t.Fatal("expected target to still be healthy after one failure")
+	}
+
+	h.check(ctx, now.Add(2*time.Second))
+	if target.Healthy.Load() {
+		t.Fatal("expected target to be unhealthy after two failures")
+	}
+	if len(reports) != 2 || reports[1][0].Healthy || reports[1][0].Message == "" {
+		t.Fatalf("expected an unhealthy report with a message, got %+v", reports)
+	}
+
+	ready.Store(true)
+	h.check(ctx, now.Add(3*time.Second))
+	if !target.Healthy.Load() {
+		t.Fatal("expected target to recover after one success")
+	}
+	if len(reports) != 3 {
+		t.Fatalf("expected 3 reports, got %d", len(reports))
+	}
+}
+
+func TestHealthChecker_ReappliesStateToNewRuntime(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	store, target := newHealthCheckedRuntime(t, srv.URL)
+	h := NewHealthChecker(store, WithHealthLogger(logger.NilLogger{}))
+
+	now := time.Now()
+	h.check(context.Background(), now)
+	h.check(context.Background(), now.Add(time.Second))
+	if target.Healthy.Load() {
+		t.Fatal("expected target to be unhealthy")
+	}
+
+	// A recompile produces new targets that start out healthy
+	store2, target2 := newHealthCheckedRuntime(t, srv.URL)
+	store.Store(store2.Load())
+
+	h.check(context.Background(), now.Add(1500*time.Millisecond))
+	if target2.Healthy.Load() {
+		t.Fatal("expected known health to be applied to the new target")
+	}
+}
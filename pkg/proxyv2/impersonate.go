@@ -0,0 +1,99 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ImpersonationMode controls how the identity of clients is passed on to a
+// backend.
+type ImpersonationMode uint8
+
+const (
+	// ImpersonationNone forwards the headers of clients unchanged.
+	ImpersonationNone ImpersonationMode = iota
+	// ImpersonationIdentity impersonates the authenticated identity of clients
+	// using the credentials of the backend.
+	ImpersonationIdentity
+)
+
+// AnonymousUser is impersonated for requests without an identity.
+const AnonymousUser = "system:anonymous"
+
+// UnauthenticatedGroup is the group of requests without an identity.
+const UnauthenticatedGroup = "system:unauthenticated"
+
+const (
+	headerImpersonateUser        = "Impersonate-User"
+	headerImpersonateGroup       = "Impersonate-Group"
+	headerImpersonateExtraPrefix = "Impersonate-Extra-"
+)
+
+// impersonatedIdentity returns the identity stored in ctx, or the anonymous
+// user if there is none.
+func impersonatedIdentity(ctx context.Context) *Identity {
+	if id, ok := IdentityFromContext(ctx); ok && id.Subject != "" {
+		return id
+	}
+	return &Identity{Subject: AnonymousUser}
+}
+
+// identityGroups returns the groups of the identity stored in ctx, or the
+// unauthenticated group if there is none.
+func identityGroups(ctx context.Context) []string {
+	if id, ok := IdentityFromContext(ctx); ok && id.Subject != "" {
+		return id.Groups
+	}
+	return []string{UnauthenticatedGroup}
+}
+
+// removeImpersonation removes the Impersonate-* headers of the client from h.
+func removeImpersonation(h http.Header) {
+	for name := range h {
+		if strings.HasPrefix(name, "Impersonate-") {
+			delete(h, name)
+		}
+	}
+}
+
+// impersonate replaces the credentials and Impersonate-* headers of the
+// client in h with headers impersonating id. The client headers are removed
+// first so that clients cannot impersonate anyone else.
+func impersonate(h http.Header, id *Identity) {
+	h.Del("Authorization")
+	removeImpersonation(h)
+
+	h.Set(headerImpersonateUser, id.Subject)
+	for _, g := range id.Groups {
+		h.Add(headerImpersonateGroup, g)
+	}
+	for key, values := range id.Extra {
+		// Extra keys are case insensitive and must be escaped to be valid
+		// header names.
+		name := headerImpersonateExtraPrefix + url.PathEscape(strings.ToLower(key))
+		for _, v := range values {
+			h.Add(name, v)
+		}
+	}
+}
+
+// auditImpersonation logs the identity that r is forwarded to backend as.
+func (p *Proxy) auditImpersonation(r *http.Request, route *RouteRuntime, backend *BackendRuntime) {
+	id := impersonatedIdentity(r.Context())
+	fields := []any{
+		"route", route.Name,
+		"backend", backend.Name,
+		"user", id.Subject,
+		"groups", id.Groups,
+		"extra", id.Extra,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote", clientIPFromRequest(r),
+	}
+	if info, ok := RequestInfoFromContext(r.Context()); ok && info.IsResourceRequest {
+		fields = append(fields, "verb", info.Verb, "namespace", info.Namespace, "resource", info.Resource, "name", info.Name)
+	}
+	p.audit.Info("impersonating identity", fields...)
+}
@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// forwardAs forwards a request with the given client headers and identity to
+// a backend using mode and returns the headers received by the backend.
+func forwardAs(t *testing.T, mode ImpersonationMode, id *Identity, header http.Header) http.Header {
+	t.Helper()
+	var got http.Header
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	})
+
+	pool := newRetryPool(t, srv.URL)
+	pool.Backend.Impersonation = mode
+	h := NewForwarder(http.DefaultTransport).Handler(pool)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if id != nil {
+		req = req.WithContext(WithIdentity(context.Background(), id))
+	}
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	return got
+}
+
+// escalation holds client headers that attempt to impersonate a cluster admin.
+var escalation = http.Header{
+	"Authorization":          {"Bearer client-token"},
+	"Impersonate-User":       {"admin"},
+	"Impersonate-Group":      {"system:masters"},
+	"Impersonate-Uid":        {"0"},
+	"Impersonate-Extra-Role": {"admin"},
+}
+
+// recordingLogger records the fields of Info entries by key.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []map[string]any
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...any) {}
+func (l *recordingLogger) Warn(msg string, fields ...any)  {}
+func (l *recordingLogger) Error(msg string, fields ...any) {}
+
+func (l *recordingLogger) Info(msg string, fields ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := map[string]any{"msg": msg}
+	for i := 0; i+1 < len(fields); i += 2 {
+		entry[fmt.Sprint(fields[i])] = fields[i+1]
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Impersonation
+// ---------------------------------------------------------------------------
+
+func TestForwarder_Impersonation(t *testing.T) {
+	got := forwardAs(t, ImpersonationIdentity, &Identity{
+		Subject: "alice",
+		Groups:  []string{"dev", "ops"},
+		Extra:   map[string][]string{"Scopes": {"read", "write"}, "example.com/team": {"platform"}},
+	}, escalation)
+
+	if got.Get("Authorization") != "" {
+		t.Errorf("expected client Authorization to be removed, got %q", got.Get("Authorization"))
+	}
+	if got.Get("Impersonate-User") != "alice" {
+		t.Errorf("expected Impersonate-User alice, got %q", got.Get("Impersonate-User"))
+	}
+	if groups := got.Values("Impersonate-Group"); !slices.Equal(groups, []string{"dev", "ops"}) {
+		t.Errorf("expected Impersonate-Group [dev ops], got %v", groups)
+	}
+	if got.Get("Impersonate-Uid") != "" || got.Get("Impersonate-Extra-Role") != "" {
+		t.Errorf("expected client Impersonate-* headers to be removed, got %v", got)
+	}
+	if scopes := got.Values("Impersonate-Extra-Scopes"); !slices.Equal(scopes, []string{"read", "write"}) {
+		t.Errorf("expected Impersonate-Extra-Scopes [read write], got %v", scopes)
+	}
+	if team := got.Get("Impersonate-Extra-Example.com%2fteam"); team != "platform" {
+		t.Errorf("expected escaped extra key, got %v", got)
+	}
+}
+
+func TestForwarder_Impersonation_Anonymous(t *testing.T) {
+	got := forwardAs(t, ImpersonationIdentity, nil, escalation)
+
+	if got.Get("Impersonate-User") != AnonymousUser {
+		t.Errorf("expected Impersonate-User %s, got %q", AnonymousUser, got.Get("Impersonate-User"))
+	}
+	if len(got.Values("Impersonate-Group")) != 0 {
+		t.Errorf("expected no Impersonate-Group, got %v", got.Values("Impersonate-Group"))
+	}
+}
+
+func TestForwarder_Impersonation_None(t *testing.T) {
+	got := forwardAs(t, ImpersonationNone, &Identity{Subject: "alice"}, escalation)
+
+	if got.Get("Impersonate-User") != "admin" || got.Get("Authorization") != "Bearer client-token" {
+		t.Errorf("expected client headers to be forwarded unchanged, got %v", got)
+	}
+}
+
+func TestProxy_Impersonation_Audit(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	rr := &RouteRuntime{Name: "r"}
+	p := newRouteProxy(t, srv.URL, rr)
+	rr.BackendPool.Backend.Impersonation = ImpersonationIdentity
+
+	audit := &recordingLogger{}
+	p.audit = audit
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/pods", nil)
+	req = req.WithContext(WithIdentity(req.Context(), &Identity{Subject: "alice", Groups: []string{"dev"}}))
+	p.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(audit.entries))
+	}
+	entry := audit.entries[0]
+	if entry["backend"] != "be" || entry["user"] != "alice" || entry["verb"] != "list" || entry["namespace"] != "default" {
+		t.Errorf("unexpected audit entry %v", entry)
+	}
+}
@@ -0,0 +1,276 @@
+package proxy
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/amimof/multikube/pkg/logger"
+)
+
+// clockSkew is the leeway allowed when validating time based token claims.
+const clockSkew = 30 * time.Second
+
+// RS256Authenticator authenticates bearer tokens signed with RS256 using a
+// static RSA public key.
+type RS256Authenticator struct {
+	key    *rsa.PublicKey
+	claims *ClaimMapper
+}
+
+// NewRS256Authenticator returns an authenticator that verifies tokens using key.
+// The identity is derived from the token claims by claims, or by
+// DefaultClaimMapper if claims is nil.
+func NewRS256Authenticator(key *rsa.PublicKey, claims *ClaimMapper) *RS256Authenticator {
+	if claims == nil {
+		claims = DefaultClaimMapper()
+	}
+	return &RS256Authenticator{key: key, claims: claims}
+}
+
+// Authenticate implements Authenticator.
+func (a *RS256Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	raw, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	return verifyJWT(raw, jwt.Expected{}, a.claims, func(hdr jose.Header) (any, error) {
+		if hdr.Algorithm != string(jose.RS256) {
+			return nil, fmt.Errorf("unexpected signing algorithm %q", hdr.Algorithm)
+		}
+		return a.key, nil
+	})
+}
+
+// OIDCConfig is configuration for the OIDC authenticator
+type OIDCConfig struct {
+	IssuerURL string
+	// ClientID must be in the aud claim of tokens. It is required, as the
+	// provider issues tokens to other clients as well.
+	ClientID string
+	// UsernameClaim is the claim holding the username if Claims is nil.
+	// Defaults to sub.
+	UsernameClaim string
+	// Claims derives identities from the token claims. If nil, the username
+	// is read from UsernameClaim and the groups from the groups claim.
+	Claims             *ClaimMapper
+	PollInterval       time.Duration
+	InsecureSkipVerify bool
+	CA                 *x509.Certificate
+	Logger             logger.Logger
+}
+
+// OIDCAuthenticator authenticates bearer tokens issued by an OpenID Connect
+// provider. Signing keys are discovered and refreshed from the JWKS endpoint
+// advertised by the provider.
+type OIDCAuthenticator struct {
+	cfg    OIDCConfig
+	client *http.Client
+	keys   atomic.Pointer[jose.JSONWebKeySet]
+}
+
+// NewOIDCAuthenticator returns an OIDC authenticator. Run must be called for
+// the authenticator to fetch signing keys from the provider.
+func NewOIDCAuthenticator(cfg OIDCConfig) *OIDCAuthenticator {
+	if cfg.Claims == nil {
+		cfg.Claims, _ = NewClaimMapper(ClaimMapping{UsernameClaim: cfg.UsernameClaim})
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Minute
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = logger.ConsoleLogger{}
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // user-controlled
+	}
+	if cfg.CA != nil {
+		rootCAs, _ := x509.SystemCertPool()
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		rootCAs.AddCert(cfg.CA)
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	a := &OIDCAuthenticator{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+	a.keys.Store(&jose.JSONWebKeySet{})
+	return a
+}
+
+// Run fetches the signing keys of the provider and keeps refreshing them every
+// poll interval until ctx is cancelled.
+func (a *OIDCAuthenticator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.refresh(ctx); err != nil {
+			a.cfg.Logger.Error("error refreshing oidc signing keys", "issuer", a.cfg.IssuerURL, "error", err)
+			oidcProviderUp.WithLabelValues(a.cfg.IssuerURL).Set(0)
+		} else {
+			oidcProviderUp.WithLabelValues(a.cfg.IssuerURL).Set(1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Authenticate implements Authenticator.
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	raw, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	if a.cfg.ClientID == "" {
+		return nil, errors.New("no oidc client id configured")
+	}
+	expected := jwt.Expected{Issuer: a.cfg.IssuerURL, Audience: jwt.Audience{a.cfg.ClientID}}
+	return verifyJWT(raw, expected, a.cfg.Claims, func(hdr jose.Header) (any, error) {
+		keys := a.keys.Load().Key(hdr.KeyID)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("unknown key id %q", hdr.KeyID)
+		}
+		if !keys[0].IsPublic() {
+			return nil, fmt.Errorf("key %q is not a public key", hdr.KeyID)
+		}
+		return keys[0].Key, nil
+	})
+}
+
+// openIDConfiguration is the subset of the OpenID provider metadata the
+// authenticator needs. See https://openid.net/specs/openid-connect-discovery-1_0.html
+type openIDConfiguration struct {
+	Issuer  string `json:"issuer"`
+	JwksURI string `json:"jwks_uri"`
+}
+
+func (a *OIDCAuthenticator) refresh(ctx context.Context) error {
+	u, err := url.Parse(a.cfg.IssuerURL)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, ".well-known/openid-configuration")
+
+	var discovery openIDConfiguration
+	if err := a.getJSON(ctx, u.String(), &discovery); err != nil {
+		return fmt.Errorf("fetching openid-configuration: %w", err)
+	}
+	if discovery.Issuer != a.cfg.IssuerURL {
+		return fmt.Errorf("provider metadata has issuer %q, expected %q", discovery.Issuer, a.cfg.IssuerURL)
+	}
+	if discovery.JwksURI == "" {
+		return errors.New("provider metadata has no jwks_uri")
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := a.getJSON(ctx, discovery.JwksURI, &keys); err != nil {
+		return fmt.Errorf("fetching jwks: %w", err)
+	}
+
+	a.keys.Store(&keys)
+	return nil
+}
+
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// verifyJWT parses and verifies raw using the key returned by keyFunc, then
+// validates the registered claims against expected. The returned identity is
+// derived from the claims by claims.
+func verifyJWT(raw string, expected jwt.Expected, claims *ClaimMapper, keyFunc func(jose.Header) (any, error)) (*Identity, error) {
+	tok, err := jwt.ParseSigned(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing token: %w", err)
+	}
+	if len(tok.Headers) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+
+	key, err := keyFunc(tok.Headers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		registered jwt.Claims
+		all        map[string]any
+	)
+	if err := tok.Claims(key, &registered, &all); err != nil {
+		return nil, fmt.Errorf("verifying token: %w", err)
+	}
+
+	expected.Time = time.Now()
+	if err := registered.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return nil, fmt.Errorf("validating token: %w", err)
+	}
+
+	return claims.Identity(all)
+}
+
+// flattenClaims converts arbitrary JSON claim values into strings. Arrays of
+// strings are joined with commas, other non-string values are JSON encoded.
+func flattenClaims(in map[string]any) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		switch val := v.(type) {
+		case string:
+			out[k] = val
+		case []any:
+			parts := make([]string, 0, len(val))
+			for _, item := range val {
+				if s, ok := item.(string); ok {
+					parts = append(parts, s)
+				}
+			}
+			if len(parts) == len(val) {
+				out[k] = strings.Join(parts, ",")
+				continue
+			}
+			b, _ := json.Marshal(val)
+			out[k] = string(b)
+		default:
+			b, _ := json.Marshal(val)
+			out[k] = string(b)
+		}
+	}
+	return out
+}
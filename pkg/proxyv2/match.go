@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Matches returns true if all conditions of the route match r.
+func (rr *RouteRuntime) Matches(r *http.Request) bool {
+	if !inSet(rr.Methods, r.Method) {
+		return false
+	}
+	if rr.Path != "" {
+		if ok, err := path.Match(rr.Path, r.URL.Path); err != nil || !ok {
+			return false
+		}
+	}
+	if rr.PathPrefix != "" && !hasPathPrefix(r.URL.Path, rr.PathPrefix) {
+		return false
+	}
+	if rr.PathRegex != nil && !rr.PathRegex.MatchString(r.URL.Path) {
+		return false
+	}
+	if rr.Header != nil && !rr.Header.matches(r.Header) {
+		return false
+	}
+	for _, h := range rr.Headers {
+		if !h.matches(r.Header) {
+			return false
+		}
+	}
+	if len(rr.QueryParams) > 0 {
+		q := r.URL.Query()
+		for _, qp := range rr.QueryParams {
+			if !qp.matches(q) {
+				return false
+			}
+		}
+	}
+	if !rr.matchesRequestInfo(r) {
+		return false
+	}
+	if rr.SNI != "" && !matchSNI(rr.SNI, requestSNI(r)) {
+		return false
+	}
+	if rr.JWT != nil {
+		claims, _ := JWTClaimsFromContext(r.Context())
+		if value, ok := claims[rr.JWT.Claim]; !ok || value != rr.JWT.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Conditions returns the number of match conditions of the route.
+func (rr *RouteRuntime) Conditions() int {
+	n := len(rr.Headers) + len(rr.QueryParams)
+	for _, set := range []bool{
+		rr.Path != "",
+		rr.PathPrefix != "",
+		rr.PathRegex != nil,
+		rr.Header != nil,
+		rr.SNI != "",
+		rr.JWT != nil,
+		rr.Methods != nil,
+		rr.Namespace != nil,
+		rr.APIGroups != nil,
+		rr.Resources != nil,
+		rr.Verbs != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// matchesRequestInfo matches the Kubernetes attributes of r, as forwarded by
+// the route. Routes with any such condition never match non-resource
+// requests.
+func (rr *RouteRuntime) matchesRequestInfo(r *http.Request) bool {
+	if rr.Namespace == nil && rr.APIGroups == nil && rr.Resources == nil && rr.Verbs == nil {
+		return true
+	}
+
+	info := rr.requestInfo(r)
+	if !info.IsResourceRequest {
+		return false
+	}
+
+	if rr.Namespace != nil && !rr.Namespace.matches(info.Namespace) {
+		return false
+	}
+	if !inSet(rr.APIGroups, info.APIGroup) {
+		return false
+	}
+	resource := info.Resource
+	if info.Subresource != "" {
+		resource += "/" + info.Subresource
+	}
+	if !inSet(rr.Resources, resource) {
+		return false
+	}
+	return inSet(rr.Verbs, info.Verb)
+}
+
+// hasPathPrefix returns true if p starts with prefix at a segment boundary,
+// so that /clusters/prod matches /clusters/prod/api but not
+// /clusters/production.
+func hasPathPrefix(p, prefix string) bool {
+	rest, ok := strings.CutPrefix(p, prefix)
+	return ok && (rest == "" || rest[0] == '/' || strings.HasSuffix(prefix, "/"))
+}
+
+// inSet returns true if set is nil or contains v.
+func inSet(set map[string]struct{}, v string) bool {
+	if set == nil {
+		return true
+	}
+	_, ok := set[v]
+	return ok
+}
+
+func (n *NamespaceRuntime) matches(ns string) bool {
+	switch {
+	case ns == "":
+		return false
+	case n.Regex != nil:
+		return n.Regex.MatchString(ns)
+	case n.Prefix != "":
+		return strings.HasPrefix(ns, n.Prefix)
+	default:
+		return ns == n.Exact
+	}
+}
+
+func (h *HeaderRuntime) matches(header http.Header) bool {
+	values, ok := header[h.Canonical]
+	var v string
+	if ok && len(values) > 0 {
+		v = values[0]
+	}
+	if h.Value == "" && h.Regex == nil && !h.Present && !h.Absent {
+		return v == ""
+	}
+	return matchValue(v, ok, h.Value, h.Regex, h.Present, h.Absent)
+}
+
+func (qp *QueryParamRuntime) matches(q map[string][]string) bool {
+	values, ok := q[qp.Name]
+	var v string
+	if ok && len(values) > 0 {
+		v = values[0]
+	}
+	return matchValue(v, ok, qp.Value, qp.Regex, qp.Present, qp.Absent)
+}
+
+// matchValue matches the value v, where ok tells whether it was set at all.
+func matchValue(v string, ok bool, exact string, re *regexp.Regexp, present, absent bool) bool {
+	switch {
+	case present:
+		return ok
+	case absent:
+		return !ok
+	case re != nil:
+		return ok && re.MatchString(v)
+	default:
+		return ok && v == exact
+	}
+}
+
+// matchSNI returns true if sni matches pattern. A pattern starting with "*."
+// matches exactly one label in its place.
+func matchSNI(pattern, sni string) bool {
+	if sni == "" {
+		return false
+	}
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok && strings.HasPrefix(suffix, ".") {
+		label, found := strings.CutSuffix(strings.ToLower(sni), suffix)
+		return found && label != "" && !strings.Contains(label, ".")
+	}
+	return strings.EqualFold(pattern, sni)
+}
+
+// requestSNI returns the TLS server name of r, preferring one stored in the
+// request context.
+func requestSNI(r *http.Request) string {
+	if sni, ok := SNIFromContext(r.Context()); ok && sni != "" {
+		return sni
+	}
+	if r.TLS != nil {
+		return r.TLS.ServerName
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package proxy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// Authentication
+	authnRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_authentication_requests_total",
+		Help: "A counter for authenticated requests by result.",
+	},
+		[]string{"result"},
+	)
+	oidcProviderUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_oidc_provider_up",
+		Help: "A gauge that is 1 if signing keys were fetched from the OIDC provider on the last attempt.",
+	},
+		[]string{"issuer"},
+	)
+
+	// Health checks
+	healthChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_health_checks_total",
+		Help: "A counter for backend health check probes by result.",
+	},
+		[]string{"backend", "result"},
+	)
+	backendTargetHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_backend_target_healthy",
+		Help: "A gauge that is 1 if the backend target is considered healthy by active health checks.",
+	},
+		[]string{"backend", "target"},
+	)
+
+	// Outlier detection and circuit breaking
+	outlierEjections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_outlier_ejections_total",
+		Help: "A counter for backend targets ejected by outlier detection.",
+	},
+		[]string{"backend", "target"},
+	)
+	backendTargetEjected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_backend_target_ejected",
+		Help: "A gauge that is 1 if the backend target is currently ejected by outlier detection.",
+	},
+		[]string{"backend", "target"},
+	)
+	circuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_circuit_breaker_state",
+		Help: "A gauge for the circuit breaker state of a backend. 0 is closed, 1 is open and 2 is half-open.",
+	},
+		[]string{"backend"},
+	)
+	circuitBreakerRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_circuit_breaker_rejected_total",
+		Help: "A counter for requests rejected by an open circuit breaker.",
+	},
+		[]string{"backend"},
+	)
+
+	// Retries
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_retries_total",
+		Help: "A counter for retried upstream requests by reason.",
+	},
+		[]string{"backend", "reason"},
+	)
+	retryBudgetExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_retry_budget_exhausted_total",
+		Help: "A counter for retries that were skipped because the retry budget was exhausted.",
+	},
+		[]string{"backend"},
+	)
+
+	// Streaming
+	streamingSessions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_streaming_sessions",
+		Help: "A gauge for open upgraded streaming sessions such as exec, attach and port-forward.",
+	},
+		[]string{"backend", "protocol"},
+	)
+	streamingSessionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_streaming_sessions_total",
+		Help: "A counter for upgraded streaming sessions.",
+	},
+		[]string{"backend", "protocol"},
+	)
+	streamingBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_streaming_bytes_total",
+		Help: "A counter for bytes copied over upgraded streaming sessions by direction.",
+	},
+		[]string{"backend", "direction"},
+	)
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_cache_requests_total",
+		Help: "A counter for cacheable requests by result, one of hit, miss or revalidated.",
+	},
+		[]string{"backend", "result"},
+	)
+
+	// Rate limiting
+	rateLimitDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_rate_limit_decisions_total",
+		Help: "A counter for rate limit decisions by key and decision, one of allowed or limited.",
+	},
+		[]string{"route", "key", "decision"},
+	)
+
+	// Flow control
+	flowControlInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_flow_control_in_flight_requests",
+		Help: "A gauge for requests holding a seat of a priority level.",
+	},
+		[]string{"backend", "priority_level"},
+	)
+	flowControlQueued = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_flow_control_queued_requests",
+		Help: "A gauge for requests waiting for a seat of a priority level.",
+	},
+		[]string{"backend", "priority_level"},
+	)
+	flowControlRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_flow_control_rejected_requests_total",
+		Help: "A counter for requests rejected by flow control by reason, one of queue-full, time-out, cancelled or long-running-limit.",
+	},
+		[]string{"backend", "priority_level", "reason"},
+	)
+	flowControlLongRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multikube_proxy_flow_control_long_running_requests",
+		Help: "A gauge for long running requests in flight, such as watches and streaming sessions.",
+	},
+		[]string{"backend"},
+	)
+
+	// Fan-out
+	fanOutRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_fan_out_requests_total",
+		Help: "A counter for list requests fanned out to a backend by result, one of success or failure.",
+	},
+		[]string{"backend", "result"},
+	)
+
+	// Authorization
+	policyDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_policy_decisions_total",
+		Help: "A counter for authorization decisions by policy and decision, one of allowed or denied. The policy is empty if no policy matched.",
+	},
+		[]string{"route", "policy", "decision"},
+	)
+	admissionDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multikube_proxy_admission_decisions_total",
+		Help: "A counter for the objects of requests checked by a policy by decision, one of admitted or denied.",
+	},
+		[]string{"route", "policy", "decision"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		authnRequests,
+		oidcProviderUp,
+		healthChecks,
+		backendTargetHealthy,
+		outlierEjections,
+		backendTargetEjected,
+		circuitBreakerState,
+		circuitBreakerRejected,
+		retries,
+		retryBudgetExhausted,
+		streamingSessions,
+		streamingSessionsTotal,
+		streamingBytes,
+		cacheRequests,
+		rateLimitDecisions,
+		flowControlInFlight,
+		flowControlQueued,
+		flowControlRejected,
+		flowControlLongRunning,
+		fanOutRequests,
+		policyDecisions,
+		admissionDecisions,
+	)
+}
@@ -0,0 +1,217 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+)
+
+// Defaults for passive outlier detection and circuit breaking.
+const (
+	DefaultConsecutiveErrors   = 5
+	DefaultBaseEjectionTime    = 30 * time.Second
+	DefaultMaxEjectionTime     = 5 * time.Minute
+	DefaultErrorRateThreshold  = 50
+	DefaultMinimumRequests     = 20
+	DefaultCircuitWindow       = 10 * time.Second
+	DefaultCircuitOpenDuration = 30 * time.Second
+)
+
+// OutlierDetectionRuntime is the compiled outlier detection configuration of a
+// backend. A target is ejected after ConsecutiveErrors failed requests. Each
+// ejection lasts twice as long as the previous one, starting at
+// BaseEjectionTime and capped at MaxEjectionTime.
+type OutlierDetectionRuntime struct {
+	ConsecutiveErrors int
+	BaseEjectionTime  time.Duration
+	MaxEjectionTime   time.Duration
+}
+
+// ejectionTime returns how long a target is ejected for the n:th time.
+func (o *OutlierDetectionRuntime) ejectionTime(n int64) time.Duration {
+	d := o.BaseEjectionTime
+	for i := int64(1); i < n && d < o.MaxEjectionTime; i++ {
+		d *= 2
+	}
+	return min(d, o.MaxEjectionTime)
+}
+
+// Ejected returns true and the time the ejection ends if the target is
+// currently ejected by outlier detection.
+func (t *BackendTarget) Ejected(now time.Time) (time.Time, bool) {
+	until := t.ejectedUntil.Load()
+	if until == 0 || now.UnixNano() >= until {
+		return time.Time{}, false
+	}
+	return time.Unix(0, until), true
+}
+
+// Available returns true if the target is healthy and not ejected.
+func (t *BackendTarget) Available(now time.Time) bool {
+	if !t.Healthy.Load() {
+		return false
+	}
+	_, ejected := t.Ejected(now)
+	return !ejected
+}
+
+// observe records the outcome of a request for outlier detection and ejects
+// the target once it has failed too many times in a row. Successful requests
+// reset the consecutive error count as well as the ejection backoff.
+func (t *BackendTarget) observe(od *OutlierDetectionRuntime, failed bool, now time.Time) {
+	if od == nil {
+		return
+	}
+
+	if !failed {
+		t.consecutiveErrors.Store(0)
+		t.ejections.Store(0)
+		return
+	}
+
+	if t.consecutiveErrors.Add(1) < int64(od.ConsecutiveErrors) {
+		return
+	}
+
+	if _, ejected := t.Ejected(now); ejected {
+		return
+	}
+
+	t.consecutiveErrors.Store(0)
+	n := t.ejections.Add(1)
+	t.ejectedUntil.Store(now.Add(od.ejectionTime(n)).UnixNano())
+
+	backendName := ""
+	if t.Backend != nil {
+		backendName = t.Backend.Name
+	}
+	outlierEjections.WithLabelValues(backendName, t.ID).Inc()
+}
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState uint8
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker rejects requests to a backend once the rate of failed
+// requests within Window crosses ErrorRateThreshold percent. The circuit stays
+// open for OpenDuration after which a single trial request is let through. The
+// circuit closes if the trial succeeds and opens again if it fails.
+type CircuitBreaker struct {
+	ErrorRateThreshold int
+	MinimumRequests    int
+	Window             time.Duration
+	OpenDuration       time.Duration
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trial       bool
+}
+
+// Allow reports whether a request may be sent to the backend. If not, the
+// second return value is the time until the next trial request is let through.
+func (cb *CircuitBreaker) Allow(now time.Time) (bool, time.Duration) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if wait := cb.openedAt.Add(cb.OpenDuration).Sub(now); wait > 0 {
+			return false, wait
+		}
+		cb.state = CircuitHalfOpen
+		cb.trial = true
+		return true, 0
+	case CircuitHalfOpen:
+		if cb.trial {
+			return false, time.Second
+		}
+		cb.trial = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+// Record records the outcome of a request that was allowed by Allow.
+func (cb *CircuitBreaker) Record(failed bool, now time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen {
+		cb.trial = false
+		if failed {
+			cb.open(now)
+			return
+		}
+		cb.state = CircuitClosed
+		cb.resetWindow(now)
+		return
+	}
+
+	if cb.state != CircuitClosed {
+		return
+	}
+
+	if now.Sub(cb.windowStart) >= cb.Window {
+		cb.resetWindow(now)
+	}
+
+	cb.requests++
+	if failed {
+		cb.failures++
+	}
+
+	if cb.requests >= cb.MinimumRequests && cb.failures*100 >= cb.ErrorRateThreshold*cb.requests {
+		cb.open(now)
+	}
+}
+
+// Abort releases a request that was allowed by Allow without an outcome, for
+// example because the client went away.
+func (cb *CircuitBreaker) Abort() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.trial = false
+}
+
+// State returns the state of the circuit at now.
+func (cb *CircuitBreaker) State(now time.Time) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && !now.Before(cb.openedAt.Add(cb.OpenDuration)) {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+func (cb *CircuitBreaker) open(now time.Time) {
+	cb.state = CircuitOpen
+	cb.openedAt = now
+	cb.resetWindow(now)
+}
+
+func (cb *CircuitBreaker) resetWindow(now time.Time) {
+	cb.windowStart = now
+	cb.requests = 0
+	cb.failures = 0
+}
@@ -0,0 +1,166 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func newServerTarget(t *testing.T, br *BackendRuntime, server string) *BackendTarget {
+	t.Helper()
+	u, err := url.Parse(server)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+	target := &BackendTarget{ID: u.String(), URL: u, Backend: br}
+	target.Healthy.Store(true)
+	return target
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Outlier detection
+// ---------------------------------------------------------------------------
+
+func TestForwarder_OutlierDetection_EjectsFailingTarget(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer good.Close()
+
+	br := &BackendRuntime{
+		Name: "be",
+		OutlierDetection: &OutlierDetectionRuntime{
+			ConsecutiveErrors: 2,
+			BaseEjectionTime:  time.Minute,
+			MaxEjectionTime:   time.Hour,
+		},
+	}
+	badTarget := newServerTarget(t, br, bad.URL)
+	br.Targets = []*BackendTarget{badTarget, newServerTarget(t, br, good.URL)}
+	pool := &BackendPool{Name: "be", Targets: br.Targets, Backend: br}
+
+	h := NewForwarder(http.DefaultTransport).Handler(pool)
+
+	failures := 0
+	for range 10 {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+		if rr.Code != http.StatusOK {
+			failures++
+		}
+	}
+
+	if failures != 2 {
+		t.Errorf("expected 2 failed requests before ejection, got %d", failures)
+	}
+	if _, ejected := badTarget.Ejected(time.Now()); !ejected {
+		t.Error("expected failing target to be ejected")
+	}
+	if until, _ := badTarget.Ejected(time.Now()); until.Before(time.Now().Add(59 * time.Second)) {
+		t.Errorf("expected ejection for the base ejection time, ejected until %v", until)
+	}
+}
+
+func TestOutlierDetection_EjectionBackoff(t *testing.T) {
+	od := &OutlierDetectionRuntime{BaseEjectionTime: 10 * time.Second, MaxEjectionTime: 30 * time.Second}
+
+	tests := []struct {
+		n    int64
+		want time.Duration
+	}{
+		{n: 1, want: 10 * time.Second},
+		{n: 2, want: 20 * time.Second},
+		{n: 3, want: 30 * time.Second},
+		{n: 10, want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := od.ejectionTime(tt.n); got != tt.want {
+			t.Errorf("ejection %d: expected %v, got %v", tt.n, tt.want, got)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Circuit breaker
+// ---------------------------------------------------------------------------
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	cb := &CircuitBreaker{
+		ErrorRateThreshold: 50,
+		MinimumRequests:    4,
+		Window:             10 * time.Second,
+		OpenDuration:       5 * time.Second,
+	}
+	now := time.Now()
+
+	for i, failed := range []bool{false, true, false, true} {
+		if ok, _ := cb.Allow(now); !ok {
+			t.Fatalf("request %d: expected circuit to allow request", i)
+		}
+		cb.Record(failed, now)
+	}
+
+	if got := cb.State(now); got != CircuitOpen {
+		t.Fatalf("expected circuit to be open, got %s", got)
+	}
+	if ok, wait := cb.Allow(now.Add(time.Second)); ok || wait != 4*time.Second {
+		t.Fatalf("expected request to be rejected with 4s wait, got allowed=%v wait=%v", ok, wait)
+	}
+
+	// A single trial request is let through once the open duration has passed
+	later := now.Add(5 * time.Second)
+	if ok, _ := cb.Allow(later); !ok {
+		t.Fatal("expected trial request to be allowed")
+	}
+	if ok, _ := cb.Allow(later); ok {
+		t.Fatal("expected concurrent request to be rejected while half-open")
+	}
+
+	// A failed trial opens the circuit again
+	cb.Record(true, later)
+	if got := cb.State(later); got != CircuitOpen {
+		t.Fatalf("expected circuit to be open after failed trial, got %s", got)
+	}
+
+	// A successful trial closes it
+	later = later.Add(5 * time.Second)
+	if ok, _ := cb.Allow(later); !ok {
+		t.Fatal("expected trial request to be allowed")
+	}
+	cb.Record(false, later)
+	if got := cb.State(later); got != CircuitClosed {
+		t.Fatalf("expected circuit to be closed after successful trial, got %s", got)
+	}
+}
+
+func TestForwarder_CircuitBreaker_RejectsWithStatus(t *testing.T) {
+	br := &BackendRuntime{
+		Name: "be",
+		CircuitBreaker: &CircuitBreaker{
+			ErrorRateThreshold: 50,
+			MinimumRequests:    1,
+			Window:             time.Minute,
+			OpenDuration:       time.Minute,
+		},
+	}
+	br.CircuitBreaker.Record(true, time.Now())
+	pool := &BackendPool{Name: "be", Backend: br}
+
+	rr := httptest.NewRecorder()
+	NewForwarder(http.DefaultTransport).Handler(pool).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", rr.Code)
+	}
+	if rr.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+}
@@ -0,0 +1,295 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// PolicyEffect is what a Policy does with the requests it matches.
+type PolicyEffect uint8
+
+const (
+	PolicyEffectAllow PolicyEffect = iota + 1
+	PolicyEffectDeny
+)
+
+// Policy allows or denies the requests matching a CEL expression over the
+// variables user, request, backend and now, and checks their objects.
+type Policy struct {
+	Name string
+	// Effect is zero for policies that only check objects.
+	Effect PolicyEffect
+	// Message is returned to clients denied by the policy.
+	Message string
+	// Routes is nil if the policy applies to all routes.
+	Routes map[string]struct{}
+
+	// Validations and Mutation check the objects of the requests the policy
+	// matches. See Admission.
+	Validations []*Validation
+	Mutation    *Mutation
+
+	program cel.Program
+}
+
+// policyEnv declares the variables of policy expressions. Its variables are
+// those returned by policyVariables.
+var policyEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("user", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("backend", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+})
+
+// NewPolicy returns a Policy matching the requests for which expression
+// evaluates to true. It returns an error if expression fails to compile or
+// does not evaluate to a bool.
+func NewPolicy(name string, effect PolicyEffect, expression string) (*Policy, error) {
+	env, err := policyEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", t)
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{Name: name, Effect: effect, program: prg}, nil
+}
+
+func (p *Policy) appliesTo(route *RouteRuntime) bool {
+	if p.Routes == nil {
+		return true
+	}
+	_, ok := p.Routes[route.Name]
+	return ok
+}
+
+// matches evaluates the expression of p with vars.
+func (p *Policy) matches(vars map[string]any) (bool, error) {
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	if out.Type() != types.BoolType {
+		return false, fmt.Errorf("expression must evaluate to a bool, got %s", out.Type())
+	}
+	return out.Value().(bool), nil
+}
+
+// Authorizer decides whether requests are allowed by evaluating policies.
+//
+// Deny policies take precedence over allow policies. Once an allow policy
+// applies to a route, requests to the route are denied unless they match
+// one. Requests to other routes are allowed unless a deny policy matches. An
+// error evaluating a deny policy denies the request. Lists fanned out to
+// several backends are decided once for each backend and denied unless all
+// of them allow it.
+type Authorizer struct {
+	Policies []*Policy
+}
+
+// Decision is the outcome of authorizing a request.
+type Decision struct {
+	Allowed bool
+	// Policy is the name of the policy that decided, or empty if no policy
+	// matched.
+	Policy string
+	Reason string
+}
+
+// Authorize decides whether r, matched to route, is allowed at now. A nil
+// Authorizer allows all requests.
+func (a *Authorizer) Authorize(r *http.Request, route *RouteRuntime, now time.Time) Decision {
+	if a == nil {
+		return Decision{Allowed: true}
+	}
+
+	// Lists fanned out to several backends must be allowed for each of them.
+	var d Decision
+	for _, backend := range requestBackends(r, route) {
+		if d = a.decide(r, route, backend, now); !d.Allowed {
+			break
+		}
+	}
+	decision := "allowed"
+	if !d.Allowed {
+		decision = "denied"
+	}
+	policyDecisions.WithLabelValues(route.Name, d.Policy, decision).Inc()
+	return d
+}
+
+func (a *Authorizer) decide(r *http.Request, route *RouteRuntime, backend *BackendRuntime, now time.Time) Decision {
+	var (
+		vars       = policyVariables(r, backend, now)
+		restricted bool
+		allowed    *Policy
+	)
+	for _, p := range a.Policies {
+		if p.Effect == 0 || !p.appliesTo(route) {
+			continue
+		}
+		if p.Effect == PolicyEffectAllow {
+			restricted = true
+			if allowed != nil {
+				continue
+			}
+		}
+
+		ok, err := p.matches(vars)
+		switch {
+		case p.Effect == PolicyEffectDeny && err != nil:
+			return Decision{Policy: p.Name, Reason: fmt.Sprintf("policy %q failed: %v", p.Name, err)}
+		case p.Effect == PolicyEffectDeny && ok:
+			return Decision{Policy: p.Name, Reason: p.reason()}
+		case ok:
+			allowed = p
+		}
+	}
+
+	switch {
+	case allowed != nil:
+		return Decision{Allowed: true, Policy: allowed.Name, Reason: fmt.Sprintf("allowed by policy %q", allowed.Name)}
+	case restricted:
+		return Decision{Reason: "no policy allows the request"}
+	default:
+		return Decision{Allowed: true}
+	}
+}
+
+func (p *Policy) reason() string {
+	if p.Message != "" {
+		return p.Message
+	}
+	return fmt.Sprintf("denied by policy %q", p.Name)
+}
+
+// requestBackends returns the backends r is sent to by route: those of its
+// fan-out for lists, or else the backend of its pool. The backend is nil if
+// the route has none.
+func requestBackends(r *http.Request, route *RouteRuntime) []*BackendRuntime {
+	if route.FanOut.fansOut(r) && len(route.FanOut.Backends) > 0 {
+		backends := make([]*BackendRuntime, 0, len(route.FanOut.Backends))
+		for _, b := range route.FanOut.Backends {
+			backends = append(backends, b.Backend)
+		}
+		return backends
+	}
+	return []*BackendRuntime{routeBackend(route)}
+}
+
+// routeBackend returns the backend of the pool of route, or nil if it has
+// none.
+func routeBackend(route *RouteRuntime) *BackendRuntime {
+	if pool := route.BackendPool; pool != nil {
+		return pool.Backend
+	}
+	return nil
+}
+
+// policyVariables returns the variables policy expressions are evaluated
+// with for r sent to backend.
+func policyVariables(r *http.Request, backend *BackendRuntime, now time.Time) map[string]any {
+	id := impersonatedIdentity(r.Context())
+	extra := id.Extra
+	if extra == nil {
+		extra = map[string][]string{}
+	}
+
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+
+	be := map[string]any{"name": "", "labels": map[string]string{}}
+	if backend != nil {
+		be["name"] = backend.Name
+		if backend.Labels != nil {
+			be["labels"] = backend.Labels
+		}
+	}
+
+	return map[string]any{
+		"user": map[string]any{
+			"name":   id.Subject,
+			"groups": identityGroups(r.Context()),
+			"extra":  extra,
+		},
+		"request": map[string]any{
+			"verb":              info.Verb,
+			"apiGroup":          info.APIGroup,
+			"apiVersion":        info.APIVersion,
+			"namespace":         info.Namespace,
+			"resource":          info.Resource,
+			"subresource":       info.Subresource,
+			"name":              info.Name,
+			"path":              info.Path,
+			"isResourceRequest": info.IsResourceRequest,
+		},
+		"backend": be,
+		"now":     now,
+	}
+}
+
+// auditDenied logs that r was denied by policy.
+func (p *Proxy) auditDenied(r *http.Request, route *RouteRuntime, d Decision) {
+	id := impersonatedIdentity(r.Context())
+	fields := []any{
+		"route", route.Name,
+		"policy", d.Policy,
+		"reason", d.Reason,
+		"user", id.Subject,
+		"groups", id.Groups,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote", clientIPFromRequest(r),
+	}
+	if info, ok := RequestInfoFromContext(r.Context()); ok && info.IsResourceRequest {
+		fields = append(fields, "verb", info.Verb, "namespace", info.Namespace, "resource", info.Resource, "name", info.Name)
+	}
+	p.audit.Info("request denied by policy", fields...)
+}
+
+// writeForbidden writes a 403 Status for r in the words of the Kubernetes API
+// server, followed by reason.
+func writeForbidden(w http.ResponseWriter, r *http.Request, reason string) {
+	id := impersonatedIdentity(r.Context())
+	info, ok := RequestInfoFromContext(r.Context())
+	if !ok {
+		info = NewRequestInfo(r)
+	}
+
+	var msg strings.Builder
+	if info.IsResourceRequest {
+		resource := info.Resource
+		if info.Subresource != "" {
+			resource += "/" + info.Subresource
+		}
+		fmt.Fprintf(&msg, "%s is forbidden: User %q cannot %s resource %q in API group %q", resource, id.Subject, info.Verb, resource, info.APIGroup)
+		if info.Namespace != "" {
+			fmt.Fprintf(&msg, " in the namespace %q", info.Namespace)
+		} else {
+			msg.WriteString(" at the cluster scope")
+		}
+	} else {
+		fmt.Fprintf(&msg, "forbidden: User %q cannot %s path %q", id.Subject, info.Verb, info.Path)
+	}
+	msg.WriteString(": " + reason)
+
+	writeStatus(w, http.StatusForbidden, StatusReasonForbidden, msg.String())
+}
@@ -0,0 +1,191 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func newTestPolicy(t *testing.T, name string, effect PolicyEffect, expression string) *Policy {
+	t.Helper()
+	p, err := NewPolicy(name, effect, expression)
+	if err != nil {
+		t.Fatalf("compile policy %s: %v", name, err)
+	}
+	return p
+}
+
+// policyRequest returns a request of id for target with its RequestInfo.
+func policyRequest(id *Identity, method, target string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	if id != nil {
+		req = req.WithContext(WithIdentity(req.Context(), id))
+	}
+	return req.WithContext(WithRequestInfo(req.Context(), NewRequestInfo(req)))
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Policies
+// ---------------------------------------------------------------------------
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		`user.name ==`,
+		`unknown.name == "bob"`,
+		`"bob"`,
+	} {
+		if _, err := NewPolicy("p", PolicyEffectAllow, expr); err == nil {
+			t.Errorf("expected %q to fail to compile", expr)
+		}
+	}
+}
+
+func TestAuthorizer_Variables(t *testing.T) {
+	route := &RouteRuntime{
+		Name:        "r",
+		BackendPool: &BackendPool{Backend: &BackendRuntime{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+	}
+	bob := &Identity{Subject: "bob", Groups: []string{"dev"}, Extra: map[string][]string{"team": {"platform"}}}
+	now := time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		id   *Identity
+		want bool
+	}{
+		{expr: `user.name == "bob" && "dev" in user.groups && user.extra.team == ["platform"]`, id: bob, want: true},
+		{expr: `user.name == "system:anonymous" && user.groups == ["system:unauthenticated"]`, want: true},
+		{expr: `request.verb == "delete" && request.namespace == "dev" && request.resource == "deployments" && request.apiGroup == "apps" && request.name == "web"`, id: bob, want: true},
+		{expr: `request.isResourceRequest`, id: bob, want: true},
+		{expr: `backend.name == "prod" && backend.labels.env == "prod"`, id: bob, want: true},
+		{expr: `now.getHours() >= 9 && now.getHours() < 17`, id: bob, want: true},
+		{expr: `has(backend.labels.team)`, id: bob, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			a := &Authorizer{Policies: []*Policy{newTestPolicy(t, "p", PolicyEffectAllow, tt.expr)}}
+			req := policyRequest(tt.id, http.MethodDelete, "/apis/apps/v1/namespaces/dev/deployments/web")
+
+			if d := a.Authorize(req, route, now); d.Allowed != tt.want {
+				t.Errorf("expected allowed %t, got %+v", tt.want, d)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_Decide(t *testing.T) {
+	allowDev := newTestPolicy(t, "allow-dev", PolicyEffectAllow, `request.namespace == "dev"`)
+	denySecrets := newTestPolicy(t, "deny-secrets", PolicyEffectDeny, `request.resource == "secrets"`)
+	denySecrets.Message = "secrets are off limits"
+	denyTeam := newTestPolicy(t, "deny-team", PolicyEffectDeny, `user.extra.team == ["ops"]`)
+	onOther := newTestPolicy(t, "on-other", PolicyEffectAllow, `false`)
+	onOther.Routes = map[string]struct{}{"other": {}}
+	route := &RouteRuntime{Name: "r"}
+	bob := &Identity{Subject: "bob"}
+
+	tests := []struct {
+		name     string
+		policies []*Policy
+		target   string
+		allowed  bool
+		policy   string
+		reason   string
+	}{
+		{name: "allowed", policies: []*Policy{allowDev}, target: "/api/v1/namespaces/dev/pods", allowed: true, policy: "allow-dev"},
+		{name: "not allowed", policies: []*Policy{allowDev}, target: "/api/v1/namespaces/prod/pods", reason: "no policy allows the request"},
+		{name: "deny overrides allow", policies: []*Policy{allowDev, denySecrets}, target: "/api/v1/namespaces/dev/secrets", policy: "deny-secrets", reason: "secrets are off limits"},
+		{name: "only deny policies", policies: []*Policy{denySecrets}, target: "/api/v1/namespaces/prod/pods", allowed: true},
+		{name: "deny failed", policies: []*Policy{denyTeam}, target: "/api/v1/pods", policy: "deny-team", reason: `policy "deny-team" failed`},
+		{name: "other route", policies: []*Policy{onOther}, target: "/api/v1/pods", allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Authorizer{Policies: tt.policies}
+			d := a.Authorize(policyRequest(bob, http.MethodGet, tt.target), route, time.Now())
+
+			if d.Allowed != tt.allowed || d.Policy != tt.policy || !strings.Contains(d.Reason, tt.reason) {
+				t.Errorf("expected allowed %t by policy %q with reason %q, got %+v", tt.allowed, tt.policy, tt.reason, d)
+			}
+		})
+	}
+}
+
+func TestProxy_Policies(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r"})
+	p.runtime.Load().Authorizer = &Authorizer{Policies: []*Policy{
+		newTestPolicy(t, "bob-dev", PolicyEffectAllow, `user.name == "bob" && request.namespace == "dev"`),
+	}}
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, policyRequest(&Identity{Subject: "bob"}, http.MethodGet, "/api/v1/namespaces/dev/pods"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, policyRequest(&Identity{Subject: "bob"}, http.MethodGet, "/api/v1/namespaces/prod/pods/web"))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rec.Code)
+	}
+	var st Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	want := `pods is forbidden: User "bob" cannot get resource "pods" in API group "" in the namespace "prod": no policy allows the request`
+	if st.Reason != StatusReasonForbidden || st.Code != http.StatusForbidden || st.Message != want {
+		t.Errorf("unexpected status body: %+v", st)
+	}
+	if srv.hits.Load() != 1 {
+		t.Errorf("expected denied requests not to be forwarded, got %d requests", srv.hits.Load())
+	}
+}
+
+func TestAuthorizer_FanOut(t *testing.T) {
+	dev := &BackendRuntime{Name: "dev", Labels: map[string]string{"env": "dev"}}
+	prod := &BackendRuntime{Name: "prod", Labels: map[string]string{"env": "prod"}}
+	route := &RouteRuntime{
+		Name:        "r",
+		BackendPool: &BackendPool{Backend: dev},
+		FanOut:      &FanOutRuntime{Backends: []*FanOutBackend{{Name: "dev", Backend: dev}, {Name: "prod", Backend: prod}}},
+	}
+	a := &Authorizer{Policies: []*Policy{newTestPolicy(t, "deny-prod", PolicyEffectDeny, `backend.labels.env == "prod"`)}}
+	bob := &Identity{Subject: "bob"}
+
+	if d := a.Authorize(policyRequest(bob, http.MethodGet, "/api/v1/namespaces/dev/pods/web"), route, time.Now()); !d.Allowed {
+		t.Errorf("expected requests to the backend of the route to be allowed, got %+v", d)
+	}
+	if d := a.Authorize(policyRequest(bob, http.MethodGet, "/api/v1/namespaces/dev/pods"), route, time.Now()); d.Allowed || d.Policy != "deny-prod" {
+		t.Errorf("expected lists fanned out to prod to be denied, got %+v", d)
+	}
+}
+
+func TestProxy_Policies_Rewrite(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	rw := &RewriteRuntime{Prefix: "/clusters/prod", Replacement: "/"}
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r", Rewrite: rw}, WithRewrite(rw))
+	p.runtime.Load().Authorizer = &Authorizer{Policies: []*Policy{
+		newTestPolicy(t, "deny-secrets", PolicyEffectDeny, `request.resource == "secrets" && request.namespace == "dev"`),
+	}}
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, policyRequest(&Identity{Subject: "bob"}, http.MethodGet, "/clusters/prod/api/v1/namespaces/dev/secrets/db"))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `secrets is forbidden: User \"bob\" cannot get resource \"secrets\"`) {
+		t.Errorf("unexpected status body: %s", rec.Body.String())
+	}
+	if srv.hits.Load() != 0 {
+		t.Errorf("expected denied requests not to be forwarded, got %d requests", srv.hits.Load())
+	}
+}
@@ -5,29 +5,92 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/amimof/multikube/pkg/logger"
 )
 
 type Proxy struct {
 	runtime *RuntimeStore
+	audit   logger.Logger
+}
+
+// ProxyOption configures a Proxy.
+type ProxyOption func(*Proxy)
+
+// WithAuditLogger logs audit events, such as the identity impersonated toward
+// a backend, to l.
+func WithAuditLogger(l logger.Logger) ProxyOption {
+	return func(p *Proxy) {
+		p.audit = l
+	}
 }
 
-func NewProxy(runtime *RuntimeStore) *Proxy {
-	return &Proxy{runtime: runtime}
+func NewProxy(runtime *RuntimeStore, opts ...ProxyOption) *Proxy {
+	p := &Proxy{runtime: runtime, audit: logger.NilLogger{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	rt := p.runtime.Load()
 
+	// Resolve the Kubernetes attributes once for all handlers that follow.
+	r = r.WithContext(WithRequestInfo(r.Context(), NewRequestInfo(r)))
+
 	route, ok := rt.Match(r)
 	if !ok {
 		http.NotFound(w, r)
 		return
 	}
 
+	// The attributes of rewritten requests are those of the path forwarded
+	// to the backend.
+	if route.Rewrite != nil {
+		r = r.WithContext(WithRequestInfo(r.Context(), route.requestInfo(r)))
+	}
+
+	if d := rt.Authorizer.Authorize(r, route, time.Now()); !d.Allowed {
+		p.auditDenied(r, route, d)
+		writeForbidden(w, r, d.Reason)
+		return
+	}
+
+	if l, key, wait := allowRateLimits(r, route, time.Now()); l != nil {
+		writeTooManyRequests(w, route.Name, l, key, wait)
+		return
+	}
+
+	if fc := route.BackendPool.flowControl(); fc != nil {
+		release, err := fc.acquire(r, route)
+		if err != nil {
+			if r.Context().Err() == nil {
+				writeFlowControlRejected(w, fc.Backend, err)
+			}
+			return
+		}
+		defer release()
+	}
+
+	admitted, err := rt.Admission.admit(r, route, time.Now())
+	if err != nil {
+		p.writeAdmissionError(w, r, route, err)
+		return
+	}
+	r = admitted
+
+	if pool := route.BackendPool; pool != nil && pool.Backend != nil && pool.Backend.Impersonation == ImpersonationIdentity {
+		p.auditImpersonation(r, route, pool.Backend)
+	}
+
 	handler := route.Handler
-	if route.Timeout > 0 {
+	if route.Timeout > 0 && !isLongRunning(r) {
 		handler = timeoutMiddleware(route.Timeout)(handler)
 	}
+	if route.MaxRequestBodyBytes > 0 {
+		handler = maxBodyMiddleware(route.MaxRequestBodyBytes)(handler)
+	}
 
 	handler = withRuntimeVersion(rt.Version)(handler)
 	handler.ServeHTTP(w, r)
@@ -43,6 +106,24 @@ func timeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
 	}
 }
 
+// maxBodyMiddleware rejects requests with bodies larger than n bytes. Requests
+// that announce a larger Content-Length are rejected up front, others fail
+// once more than n bytes have been read.
+func maxBodyMiddleware(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				writeRequestTooLarge(w, n)
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func withRuntimeVersion(version uint64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
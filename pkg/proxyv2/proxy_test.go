@@ -0,0 +1,127 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// newRouteProxy returns a Proxy with rr as its default route, forwarding to
+// backend.
+func newRouteProxy(t *testing.T, backend string, rr *RouteRuntime, opts ...HandlerOption) *Proxy {
+	t.Helper()
+	rr.BackendPool = newRetryPool(t, backend)
+	rr.Handler = NewForwarder(http.DefaultTransport).Handler(rr.BackendPool, opts...)
+
+	store := NewRuntimeStore()
+	store.Store(&RuntimeConfig{
+		Routes:   CompiledRoutes{Default: rr},
+		Backends: map[string]*BackendRuntime{},
+	})
+	return NewProxy(store)
+}
+
+// slow waits for d before answering, or until the request is cancelled.
+func slow(d time.Duration) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(d):
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Route settings
+// ---------------------------------------------------------------------------
+
+func TestProxy_Timeout(t *testing.T) {
+	srv := newCountingServer(t, slow(time.Second))
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r", Timeout: 50 * time.Millisecond})
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", rr.Code)
+	}
+}
+
+func TestProxy_Timeout_SkipsWatch(t *testing.T) {
+	srv := newCountingServer(t, slow(100*time.Millisecond))
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r", Timeout: 20 * time.Millisecond})
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods?watch=true", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected watch to outlive the route timeout, got status %d", rr.Code)
+	}
+}
+
+func TestProxy_IdleTimeout_Watch(t *testing.T) {
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"type":"ADDED"}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r"}, WithStreamIdleTimeout(50*time.Millisecond))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rr := httptest.NewRecorder()
+		p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods?watch=true", nil))
+		done <- rr
+	}()
+
+	select {
+	case rr := <-done:
+		if rr.Body.String() != `{"type":"ADDED"}` {
+			t.Errorf("expected events before the idle timeout to be forwarded, got %q", rr.Body.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected idle watch to be closed")
+	}
+}
+
+func TestProxy_MaxRequestBodyBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    io.Reader
+		chunked bool
+		want    int
+	}{
+		{name: "within limit", body: strings.NewReader("small"), want: http.StatusOK},
+		{name: "content-length", body: strings.NewReader(strings.Repeat("x", 64)), want: http.StatusRequestEntityTooLarge},
+		{name: "chunked", body: strings.NewReader(strings.Repeat("x", 64)), chunked: true, want: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newCountingServer(t, ok)
+			p := newRouteProxy(t, srv.URL, &RouteRuntime{Name: "r", MaxRequestBodyBytes: 16})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/configmaps", tt.body)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+
+			rr := httptest.NewRecorder()
+			p.ServeHTTP(rr, req)
+
+			if rr.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, rr.Code)
+			}
+			if tt.want == http.StatusRequestEntityTooLarge && !strings.Contains(rr.Body.String(), StatusReasonRequestTooLarge) {
+				t.Errorf("expected Status with reason %s, got %q", StatusReasonRequestTooLarge, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitKey is what requests are limited by.
+type RateLimitKey int
+
+const (
+	RateLimitByRoute RateLimitKey = iota
+	RateLimitByUser
+	RateLimitByGroup
+	RateLimitByClientIP
+	RateLimitByBackend
+)
+
+// rateLimitSweepInterval is how often buckets that are full, and so no
+// different from new ones, are removed.
+const rateLimitSweepInterval = time.Minute
+
+func (k RateLimitKey) String() string {
+	switch k {
+	case RateLimitByRoute:
+		return "route"
+	case RateLimitByUser:
+		return "user"
+	case RateLimitByGroup:
+		return "group"
+	case RateLimitByClientIP:
+		return "client_ip"
+	case RateLimitByBackend:
+		return "backend"
+	default:
+		return "unknown"
+	}
+}
+
+// RateLimiter limits the rate of requests with token buckets, one for every
+// distinct key.
+type RateLimiter struct {
+	Key RateLimitKey
+	// Rate is the number of tokens added to a bucket per second.
+	Rate float64
+	// Burst is the size of a bucket.
+	Burst int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	swept   time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter of rate requests per second with
+// buckets of burst requests.
+func NewRateLimiter(key RateLimitKey, rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		Key:     key,
+		Rate:    rate,
+		Burst:   burst,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// keys returns the keys of the buckets that r must be allowed by.
+func (l *RateLimiter) keys(r *http.Request, route *RouteRuntime) []string {
+	switch l.Key {
+	case RateLimitByUser:
+		return []string{impersonatedIdentity(r.Context()).Subject}
+	case RateLimitByGroup:
+		return identityGroups(r.Context())
+	case RateLimitByClientIP:
+		return []string{clientIPFromRequest(r)}
+	case RateLimitByBackend:
+		if route.BackendPool != nil {
+			return []string{route.BackendPool.Name}
+		}
+		return []string{""}
+	default:
+		return []string{route.Name}
+	}
+}
+
+// take removes a token from the bucket of key. If the bucket is empty it
+// returns false along with the time until a token is available.
+func (l *RateLimiter) take(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.swept) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.Burst), last: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.Rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// refund returns a token taken from the bucket of key.
+func (l *RateLimiter) refund(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[key]; ok {
+		b.tokens = min(b.tokens+1, float64(l.Burst))
+	}
+}
+
+func (l *RateLimiter) refill(b *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed.Seconds()*l.Rate, float64(l.Burst))
+		b.last = now
+	}
+}
+
+// sweep removes buckets that have been refilled. l.mu must be held.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= float64(l.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
+
+type takenToken struct {
+	limiter *RateLimiter
+	key     string
+}
+
+// allowRateLimits takes a token for r from every rate limit of route. If any
+// of them is exhausted the tokens already taken are returned, and it returns
+// that rate limit along with the key of its bucket and how long to wait
+// before retrying. It returns a nil RateLimiter if r is allowed.
+func allowRateLimits(r *http.Request, route *RouteRuntime, now time.Time) (*RateLimiter, string, time.Duration) {
+	var taken []takenToken
+	for _, l := range route.RateLimits {
+		for _, key := range l.keys(r, route) {
+			ok, wait := l.take(key, now)
+			if !ok {
+				rateLimitDecisions.WithLabelValues(route.Name, l.Key.String(), "limited").Inc()
+				for _, t := range taken {
+					t.limiter.refund(t.key)
+				}
+				return l, key, wait
+			}
+			taken = append(taken, takenToken{limiter: l, key: key})
+		}
+		rateLimitDecisions.WithLabelValues(route.Name, l.Key.String(), "allowed").Inc()
+	}
+	return nil, "", 0
+}
+
+// writeTooManyRequests writes a 429 Status response naming the rate limit l
+// of route that rejected the request and the key of its bucket, asking the
+// client to retry after wait.
+func writeTooManyRequests(w http.ResponseWriter, route string, l *RateLimiter, key string, wait time.Duration) {
+	seconds := max(1, int(math.Ceil(wait.Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+
+	msg := fmt.Sprintf("rate limit of route %q exceeded", route)
+	if l.Key != RateLimitByRoute {
+		msg = fmt.Sprintf("rate limit by %s of route %q exceeded for %q", l.Key, route, key)
+	}
+	writeStatus(w, http.StatusTooManyRequests, StatusReasonTooManyRequests, fmt.Sprintf("%s, retry after %ds", msg, seconds))
+}
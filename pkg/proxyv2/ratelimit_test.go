@@ -0,0 +1,156 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func requestAs(id *Identity, remoteAddr string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.RemoteAddr = remoteAddr
+	if id != nil {
+		req = req.WithContext(WithIdentity(context.Background(), id))
+	}
+	return req
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Rate limiting
+// ---------------------------------------------------------------------------
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	l := NewRateLimiter(RateLimitByRoute, 2, 3)
+	now := time.Now()
+
+	for i := range 3 {
+		if ok, _ := l.take("r", now); !ok {
+			t.Fatalf("expected request %d within the burst to be allowed", i+1)
+		}
+	}
+
+	ok, wait := l.take("r", now)
+	if ok {
+		t.Fatal("expected request beyond the burst to be limited")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms for the next token, got %s", wait)
+	}
+
+	if ok, _ := l.take("r", now.Add(500*time.Millisecond)); !ok {
+		t.Error("expected a token to be added after 500ms")
+	}
+	if ok, _ := l.take("other", now); !ok {
+		t.Error("expected other keys to have buckets of their own")
+	}
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	l := NewRateLimiter(RateLimitByRoute, 1, 1)
+	now := time.Now()
+
+	l.take("a", now)
+	l.take("b", now.Add(rateLimitSweepInterval-time.Millisecond))
+	l.take("c", now.Add(rateLimitSweepInterval))
+
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("expected the refilled bucket to be removed")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Error("expected the bucket still being refilled to be kept")
+	}
+}
+
+func TestRateLimiter_Keys(t *testing.T) {
+	route := &RouteRuntime{Name: "r", BackendPool: &BackendPool{Name: "be"}}
+	alice := &Identity{Subject: "alice", Groups: []string{"dev", "ops"}}
+
+	tests := []struct {
+		name string
+		key  RateLimitKey
+		req  *http.Request
+		want []string
+	}{
+		{name: "route", key: RateLimitByRoute, req: requestAs(alice, "10.0.0.1:1234"), want: []string{"r"}},
+		{name: "backend", key: RateLimitByBackend, req: requestAs(alice, "10.0.0.1:1234"), want: []string{"be"}},
+		{name: "user", key: RateLimitByUser, req: requestAs(alice, "10.0.0.1:1234"), want: []string{"alice"}},
+		{name: "anonymous user", key: RateLimitByUser, req: requestAs(nil, "10.0.0.1:1234"), want: []string{AnonymousUser}},
+		{name: "groups", key: RateLimitByGroup, req: requestAs(alice, "10.0.0.1:1234"), want: []string{"dev", "ops"}},
+		{name: "unauthenticated group", key: RateLimitByGroup, req: requestAs(nil, "10.0.0.1:1234"), want: []string{UnauthenticatedGroup}},
+		{name: "no groups", key: RateLimitByGroup, req: requestAs(&Identity{Subject: "bob"}, "10.0.0.1:1234"), want: nil},
+		{name: "client ip", key: RateLimitByClientIP, req: requestAs(alice, "10.0.0.1:1234"), want: []string{"10.0.0.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRateLimiter(tt.key, 1, 1).keys(tt.req, route)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected keys %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAllowRateLimits_Refund(t *testing.T) {
+	perUser := NewRateLimiter(RateLimitByUser, 1, 2)
+	perRoute := NewRateLimiter(RateLimitByRoute, 1, 1)
+	route := &RouteRuntime{Name: "r", RateLimits: []*RateLimiter{perUser, perRoute}}
+	now := time.Now()
+
+	if l, _, _ := allowRateLimits(requestAs(nil, "10.0.0.1:1234"), route, now); l != nil {
+		t.Fatal("expected the first request to be allowed")
+	}
+	if l, key, _ := allowRateLimits(requestAs(nil, "10.0.0.1:1234"), route, now); l != perRoute || key != "r" {
+		t.Fatalf("expected the second request to be limited by the route, got %v with key %q", l, key)
+	}
+
+	// The token taken for the user by the limited request is given back.
+	if ok, _ := perUser.take(AnonymousUser, now); !ok {
+		t.Error("expected the token of the limited request to be refunded")
+	}
+}
+
+func TestProxy_RateLimit(t *testing.T) {
+	srv := newCountingServer(t, ok)
+	p := newRouteProxy(t, srv.URL, &RouteRuntime{
+		Name:       "r",
+		RateLimits: []*RateLimiter{NewRateLimiter(RateLimitByUser, 0.1, 1)},
+	})
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, requestAs(&Identity{Subject: "alice"}, "10.0.0.1:1234"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	p.ServeHTTP(rr, requestAs(&Identity{Subject: "alice"}, "10.0.0.1:1234"))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Retry-After"); got != "10" {
+		t.Errorf("expected Retry-After 10, got %q", got)
+	}
+	if !strings.Contains(rr.Body.String(), StatusReasonTooManyRequests) {
+		t.Errorf("expected Status with reason %s, got %q", StatusReasonTooManyRequests, rr.Body.String())
+	}
+	if want := `rate limit by user of route \"r\" exceeded for \"alice\"`; !strings.Contains(rr.Body.String(), want) {
+		t.Errorf("expected the message to name the rate limit, got %q", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	p.ServeHTTP(rr, requestAs(&Identity{Subject: "bob"}, "10.0.0.1:1234"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected other users not to be limited, got status %d", rr.Code)
+	}
+	if srv.hits.Load() != 2 {
+		t.Errorf("expected 2 requests to reach the backend, got %d", srv.hits.Load())
+	}
+}
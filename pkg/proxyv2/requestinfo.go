@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequestInfo holds the Kubernetes attributes of a request, resolved the same
+// way as by the Kubernetes API server.
+type RequestInfo struct {
+	// IsResourceRequest is false for requests to non-resource paths such as
+	// /healthz, /version or discovery of /apis.
+	IsResourceRequest bool
+	// Path is the URL path of the request, as forwarded to the backend.
+	Path string
+	// Verb is the Kubernetes verb of resource requests, such as get, list,
+	// watch, create, update, patch, delete, deletecollection or proxy. For
+	// non-resource requests it is the lower case HTTP method.
+	Verb string
+
+	APIPrefix   string
+	APIGroup    string
+	APIVersion  string
+	Namespace   string
+	Resource    string
+	Subresource string
+	Name        string
+
+	// Parts are the path segments following the namespace, if any.
+	Parts []string
+
+	// FieldSelector and LabelSelector are the raw selectors of list and
+	// watch requests.
+	FieldSelector string
+	LabelSelector string
+}
+
+var (
+	apiPrefixes          = map[string]struct{}{"api": {}, "apis": {}}
+	grouplessAPIPrefixes = map[string]struct{}{"api": {}}
+
+	// specialVerbs are verbs given as the first path segment following the
+	// version, as in /api/v1/watch/pods.
+	specialVerbs = map[string]struct{}{"proxy": {}, "watch": {}}
+
+	// namespaceSubresources are subresources of namespaces, as opposed to
+	// resources within them.
+	namespaceSubresources = map[string]struct{}{"status": {}, "finalize": {}}
+)
+
+// NewRequestInfo resolves the Kubernetes attributes of r. Valid inputs include:
+//
+//	/apis/{api-group}/{version}/namespaces
+//	/api/{version}/namespaces/{namespace}/{resource}
+//	/api/{version}/namespaces/{namespace}/{resource}/{name}
+//	/api/{version}/{resource}/{name}/{subresource}
+//	/api/{version}/watch/namespaces/{namespace}/{resource}
+//	/api/{version}/proxy/nodes/{name}/{path}
+//
+// Requests that do not follow these patterns are non-resource requests.
+func NewRequestInfo(r *http.Request) *RequestInfo {
+	info := &RequestInfo{
+		Path: r.URL.Path,
+		Verb: strings.ToLower(r.Method),
+	}
+
+	parts := splitPath(r.URL.Path)
+	if len(parts) < 3 {
+		// Shortest resource path is /api/{version}/{resource}
+		return info
+	}
+	if _, ok := apiPrefixes[parts[0]]; !ok {
+		return info
+	}
+
+	resolved := *info
+	resolved.APIPrefix = parts[0]
+	parts = parts[1:]
+
+	if _, ok := grouplessAPIPrefixes[resolved.APIPrefix]; !ok {
+		// Discovery of a group, as in /apis/{api-group}/{version}
+		if len(parts) < 3 {
+			return info
+		}
+		resolved.APIGroup = parts[0]
+		parts = parts[1:]
+	}
+
+	resolved.IsResourceRequest = true
+	resolved.APIVersion = parts[0]
+	parts = parts[1:]
+
+	if _, ok := specialVerbs[parts[0]]; ok {
+		// The API server fails requests such as /api/v1/watch, they are
+		// passed on as non-resource requests.
+		if len(parts) < 2 {
+			return info
+		}
+		resolved.Verb = parts[0]
+		parts = parts[1:]
+	} else {
+		switch r.Method {
+		case http.MethodPost:
+			resolved.Verb = "create"
+		case http.MethodGet, http.MethodHead:
+			resolved.Verb = "get"
+		case http.MethodPut:
+			resolved.Verb = "update"
+		case http.MethodPatch:
+			resolved.Verb = "patch"
+		case http.MethodDelete:
+			resolved.Verb = "delete"
+		default:
+			resolved.Verb = ""
+		}
+	}
+
+	if parts[0] == "namespaces" && len(parts) > 1 {
+		resolved.Namespace = parts[1]
+		// /namespaces/{namespace}/{resource}, unless it is a subresource of the
+		// namespace itself.
+		if len(parts) > 2 {
+			if _, ok := namespaceSubresources[parts[2]]; !ok {
+				parts = parts[2:]
+			}
+		}
+	}
+
+	resolved.Parts = parts
+
+	// Everything following the name of a proxy request is the proxied path.
+	if len(parts) >= 3 && resolved.Verb != "proxy" {
+		resolved.Subresource = parts[2]
+	}
+	if len(parts) >= 2 {
+		resolved.Name = parts[1]
+	}
+	if len(parts) >= 1 {
+		resolved.Resource = parts[0]
+	}
+
+	q := r.URL.Query()
+	if resolved.Name == "" && resolved.Verb == "get" {
+		resolved.Verb = "list"
+		if watch, _ := strconv.ParseBool(q.Get("watch")); watch {
+			resolved.Verb = "watch"
+		}
+		// A list or watch of a single object by name is treated as a request
+		// for that object.
+		resolved.Name = nameFromFieldSelector(q.Get("fieldSelector"))
+	}
+	if resolved.Verb == "list" || resolved.Verb == "watch" {
+		resolved.FieldSelector = q.Get("fieldSelector")
+		resolved.LabelSelector = q.Get("labelSelector")
+	}
+	if resolved.Verb == "delete" && resolved.Name == "" {
+		resolved.Verb = "deletecollection"
+	}
+
+	return &resolved
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// nameFromFieldSelector returns the value of a metadata.name requirement of
+// selector, or an empty string if there is none or it is not a valid name.
+func nameFromFieldSelector(selector string) string {
+	for req := range strings.SplitSeq(selector, ",") {
+		field, value, ok := strings.Cut(req, "=")
+		if !ok || strings.TrimSpace(field) != "metadata.name" {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+		if value == "" || value == "." || value == ".." || strings.ContainsAny(value, `/%\`) {
+			return ""
+		}
+		return value
+	}
+	return ""
+}
+
+// requestInfo resolves the Kubernetes attributes of r as it is forwarded by
+// the route, after its path is rewritten.
+func (rr *RouteRuntime) requestInfo(r *http.Request) *RequestInfo {
+	if rr.Rewrite == nil {
+		if info, ok := RequestInfoFromContext(r.Context()); ok {
+			return info
+		}
+	}
+	return NewRequestInfo(rr.Rewrite.apply(r))
+}
+
+// WithRequestInfo returns a copy of ctx carrying info.
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, ctxKeyRequestInfo, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo stored in ctx by
+// Proxy.ServeHTTP.
+func RequestInfoFromContext(ctx context.Context) (*RequestInfo, bool) {
+	info, ok := ctx.Value(ctxKeyRequestInfo).(*RequestInfo)
+	return info, ok
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNewRequestInfo(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		want   RequestInfo
+	}{
+		// Non-resource requests
+		{method: "GET", target: "/healthz", want: RequestInfo{Verb: "get"}},
+		{method: "GET", target: "/api", want: RequestInfo{Verb: "get"}},
+		{method: "GET", target: "/api/v1", want: RequestInfo{Verb: "get"}},
+		{method: "GET", target: "/apis/apps/v1", want: RequestInfo{Verb: "get"}},
+		{method: "GET", target: "/api/v1/watch", want: RequestInfo{Verb: "get"}},
+
+		// Core group
+		{method: "GET", target: "/api/v1/namespaces", want: RequestInfo{IsResourceRequest: true, Verb: "list", APIPrefix: "api", APIVersion: "v1", Resource: "namespaces"}},
+		{method: "GET", target: "/api/v1/namespaces/default", want: RequestInfo{IsResourceRequest: true, Verb: "get", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "namespaces", Name: "default"}},
+		{method: "PUT", target: "/api/v1/namespaces/default/finalize", want: RequestInfo{IsResourceRequest: true, Verb: "update", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "namespaces", Name: "default", Subresource: "finalize"}},
+		{method: "GET", target: "/api/v1/namespaces/default/pods", want: RequestInfo{IsResourceRequest: true, Verb: "list", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods"}},
+		{method: "GET", target: "/api/v1/namespaces/default/pods/web", want: RequestInfo{IsResourceRequest: true, Verb: "get", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods", Name: "web"}},
+		{method: "GET", target: "/api/v1/namespaces/default/pods/web/log", want: RequestInfo{IsResourceRequest: true, Verb: "get", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods", Name: "web", Subresource: "log"}},
+		{method: "POST", target: "/api/v1/namespaces/default/pods", want: RequestInfo{IsResourceRequest: true, Verb: "create", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods"}},
+		{method: "PATCH", target: "/api/v1/nodes/n1/status", want: RequestInfo{IsResourceRequest: true, Verb: "patch", APIPrefix: "api", APIVersion: "v1", Resource: "nodes", Name: "n1", Subresource: "status"}},
+		{method: "DELETE", target: "/api/v1/namespaces/default/pods/web", want: RequestInfo{IsResourceRequest: true, Verb: "delete", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods", Name: "web"}},
+		{method: "DELETE", target: "/api/v1/namespaces/default/pods", want: RequestInfo{IsResourceRequest: true, Verb: "deletecollection", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods"}},
+
+		// Named groups
+		{method: "GET", target: "/apis/apps/v1/deployments", want: RequestInfo{IsResourceRequest: true, Verb: "list", APIPrefix: "apis", APIGroup: "apps", APIVersion: "v1", Resource: "deployments"}},
+		{method: "PUT", target: "/apis/apps/v1/namespaces/default/deployments/web/scale", want: RequestInfo{IsResourceRequest: true, Verb: "update", APIPrefix: "apis", APIGroup: "apps", APIVersion: "v1", Namespace: "default", Resource: "deployments", Name: "web", Subresource: "scale"}},
+
+		// Watches and selectors
+		{method: "GET", target: "/api/v1/namespaces/default/pods?watch=true", want: RequestInfo{IsResourceRequest: true, Verb: "watch", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods"}},
+		{method: "GET", target: "/api/v1/watch/namespaces/default/pods", want: RequestInfo{IsResourceRequest: true, Verb: "watch", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods"}},
+		{method: "GET", target: "/api/v1/pods?labelSelector=app%3Dweb&fieldSelector=spec.nodeName%3Dn1", want: RequestInfo{IsResourceRequest: true, Verb: "list", APIPrefix: "api", APIVersion: "v1", Resource: "pods", LabelSelector: "app=web", FieldSelector: "spec.nodeName=n1"}},
+		{method: "GET", target: "/api/v1/namespaces/default/pods?watch=1&fieldSelector=metadata.name%3D%3Dweb", want: RequestInfo{IsResourceRequest: true, Verb: "watch", APIPrefix: "api", APIVersion: "v1", Namespace: "default", Resource: "pods", Name: "web", FieldSelector: "metadata.name==web"}},
+		{method: "GET", target: "/api/v1/pods?fieldSelector=metadata.name%21%3Dweb", want: RequestInfo{IsResourceRequest: true, Verb: "list", APIPrefix: "api", APIVersion: "v1", Resource: "pods", FieldSelector: "metadata.name!=web"}},
+
+		// Proxy
+		{method: "GET", target: "/api/v1/proxy/nodes/n1/metrics/cadvisor", want: RequestInfo{IsResourceRequest: true, Verb: "proxy", APIPrefix: "api", APIVersion: "v1", Resource: "nodes", Name: "n1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			got := NewRequestInfo(httptest.NewRequest(tt.method, tt.target, nil))
+			got.Path, got.Parts = "", nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestNewRequestInfo_Parts(t *testing.T) {
+	info := NewRequestInfo(httptest.NewRequest("GET", "/api/v1/namespaces/default/pods/web/log", nil))
+
+	if info.Path != "/api/v1/namespaces/default/pods/web/log" {
+		t.Errorf("unexpected path %q", info.Path)
+	}
+	if want := []string{"pods", "web", "log"}; !slices.Equal(info.Parts, want) {
+		t.Errorf("expected parts %v, got %v", want, info.Parts)
+	}
+}
+
+func TestProxy_RequestInfo(t *testing.T) {
+	var got *RequestInfo
+	rr := &RouteRuntime{Name: "r"}
+	p := newRouteProxy(t, newCountingServer(t, ok).URL, rr)
+	rr.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = RequestInfoFromContext(r.Context())
+	})
+
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/apis/apps/v1/namespaces/default/deployments", nil))
+
+	if got == nil {
+		t.Fatal("expected RequestInfo in the request context")
+	}
+	if got.Verb != "list" || got.Resource != "deployments" || got.Namespace != "default" {
+		t.Errorf("unexpected RequestInfo %+v", got)
+	}
+}
+
+func TestProxy_RequestInfo_Rewrite(t *testing.T) {
+	var got *RequestInfo
+	rr := &RouteRuntime{Name: "r", Rewrite: &RewriteRuntime{Prefix: "/clusters/prod", Replacement: "/"}}
+	p := newRouteProxy(t, newCountingServer(t, ok).URL, rr)
+	rr.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = RequestInfoFromContext(r.Context())
+	})
+
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/clusters/prod/apis/apps/v1/namespaces/dev/deployments/web", nil))
+
+	if got == nil || !got.IsResourceRequest {
+		t.Fatalf("expected the RequestInfo of the rewritten path, got %+v", got)
+	}
+	if got.Verb != "delete" || got.Namespace != "dev" || got.Resource != "deployments" || got.Name != "web" || got.Path != "/apis/apps/v1/namespaces/dev/deployments/web" {
+		t.Errorf("unexpected RequestInfo %+v", got)
+	}
+}
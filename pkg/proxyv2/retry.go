@@ -0,0 +1,291 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for retry policies.
+const (
+	DefaultRetryAttempts       = 2
+	DefaultRetryBudgetPercent  = 20
+	DefaultMinRetryConcurrency = 3
+	DefaultMaxRetryAfter       = time.Second
+)
+
+// maxRetryBodySize is the largest request body that is buffered so that the
+// request can be retried. Requests with larger bodies are sent only once.
+const maxRetryBodySize = 1 << 20
+
+var errPerTryTimeout = fmt.Errorf("per try timeout exceeded: %w", context.DeadlineExceeded)
+
+// RetryOn is a set of conditions on which a request is retried.
+type RetryOn uint8
+
+const (
+	RetryOnConnectFailure RetryOn = 1 << iota
+	RetryOnBadGateway
+	RetryOnServiceUnavailable
+	RetryOnGatewayTimeout
+	RetryOnTooManyRequests
+
+	RetryOnAll = RetryOnConnectFailure | RetryOnBadGateway | RetryOnServiceUnavailable | RetryOnGatewayTimeout | RetryOnTooManyRequests
+)
+
+// RetryPolicy is the compiled retry policy of a route. The number of retries
+// in flight is bounded by a budget of BudgetPercent of the active requests of
+// the route, but at least MinRetryConcurrency, so that a failing backend does
+// not cause a retry storm.
+type RetryPolicy struct {
+	Attempts            int
+	PerTryTimeout       time.Duration
+	RetryOn             RetryOn
+	BudgetPercent       int
+	MinRetryConcurrency int
+	MaxRetryAfter       time.Duration
+
+	active  atomic.Int64
+	retries atomic.Int64
+}
+
+// retryable returns true if r may be sent more than once.
+func (rp *RetryPolicy) retryable(r *http.Request) bool {
+	if rp == nil || rp.Attempts <= 1 {
+		return false
+	}
+	return isIdempotent(r.Method) && !isLongRunning(r)
+}
+
+// acquire reserves a retry from the budget. A successful acquire must be
+// followed by a call to release.
+func (rp *RetryPolicy) acquire() bool {
+	limit := max(int64(rp.MinRetryConcurrency), rp.active.Load()*int64(rp.BudgetPercent)/100)
+	if rp.retries.Add(1) > limit {
+		rp.retries.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (rp *RetryPolicy) release() {
+	rp.retries.Add(-1)
+}
+
+// shouldRetry decides whether the outcome of an attempt is retried. It
+// returns the reason, used for metrics, and how long to wait before retrying.
+func (rp *RetryPolicy) shouldRetry(resp *http.Response, err error) (string, time.Duration, bool) {
+	if err != nil {
+		switch {
+		case errors.Is(err, errPerTryTimeout):
+			return "timeout", 0, rp.RetryOn&RetryOnGatewayTimeout != 0
+		case isConnectFailure(err):
+			return "connect-failure", 0, rp.RetryOn&RetryOnConnectFailure != 0
+		default:
+			return "", 0, false
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway:
+		return "502", 0, rp.RetryOn&RetryOnBadGateway != 0
+	case http.StatusServiceUnavailable:
+		return "503", 0, rp.RetryOn&RetryOnServiceUnavailable != 0
+	case http.StatusGatewayTimeout:
+		return "504", 0, rp.RetryOn&RetryOnGatewayTimeout != 0
+	case http.StatusTooManyRequests:
+		if rp.RetryOn&RetryOnTooManyRequests == 0 {
+			return "", 0, false
+		}
+		wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok || wait > rp.MaxRetryAfter {
+			return "", 0, false
+		}
+		return "429", wait, true
+	default:
+		return "", 0, false
+	}
+}
+
+// roundTrip sends r to a target of the pool and retries it according to rp,
+// preferring targets that have not been tried yet. The returned cancel func
+// must be called once the response body has been consumed.
+func (f *Forwarder) roundTrip(r *http.Request, pool *BackendPool, cfg *handlerConfig) (*http.Response, context.CancelFunc, error) {
+	rp := cfg.retry
+	attempts := 1
+	var body []byte
+	if rp.retryable(r) {
+		if b, ok := bufferBody(r, maxRetryBodySize); ok {
+			attempts = rp.Attempts
+			body = b
+		}
+	}
+
+	if rp != nil {
+		rp.active.Add(1)
+		defer rp.active.Add(-1)
+	}
+
+	// Only requests that may be retried are bounded by the per try timeout.
+	var perTryTimeout time.Duration
+	if attempts > 1 {
+		perTryTimeout = rp.PerTryTimeout
+	}
+
+	var tried []*BackendTarget
+	for attempt := 1; ; attempt++ {
+		target, ok := pool.nextUntried(r, tried)
+		if !ok {
+			return nil, nil, errNoHealthyUpstream
+		}
+		tried = append(tried, target)
+
+		resp, cancel, err := f.attempt(r, target, body, perTryTimeout, cfg)
+		observeTarget(r, pool, target, resp, err)
+
+		if attempt > 1 {
+			rp.release()
+		}
+
+		if attempt >= attempts || r.Context().Err() != nil {
+			return resp, cancel, err
+		}
+
+		reason, wait, retry := rp.shouldRetry(resp, err)
+		if !retry {
+			return resp, cancel, err
+		}
+		if !rp.acquire() {
+			retryBudgetExhausted.WithLabelValues(pool.Name).Inc()
+			return resp, cancel, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+		cancel()
+		retries.WithLabelValues(pool.Name, reason).Inc()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				rp.release()
+				return nil, nil, r.Context().Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// attempt sends a single attempt of r to target. If body is non-nil it is
+// used as the request body. The attempt fails with errPerTryTimeout if it
+// takes longer than a non-zero timeout. The returned cancel func is never nil.
+func (f *Forwarder) attempt(r *http.Request, target *BackendTarget, body []byte, timeout time.Duration, cfg *handlerConfig) (*http.Response, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(r.Context())
+
+	var timedOut atomic.Bool
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			cancel()
+		})
+		defer timer.Stop()
+	}
+
+	out, err := cloneRequestForTarget(r, target, cfg.requestHeaders)
+	if err != nil {
+		cancel()
+		return nil, cancel, err
+	}
+	out = out.WithContext(ctx)
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := f.transport.RoundTrip(out)
+	if err != nil {
+		cancel()
+		if timedOut.Load() {
+			err = errPerTryTimeout
+		}
+		return nil, cancel, err
+	}
+	return resp, cancel, nil
+}
+
+// bufferBody reads the body of r into memory so that it can be replayed. It
+// returns false, leaving the body intact, if the body is larger than limit.
+func bufferBody(r *http.Request, limit int64) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil || int64(len(buf)) > limit {
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(buf), r.Body), r.Body}
+		return nil, false
+	}
+
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(buf))
+	return buf, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isLongRunning returns true for watches, log follows and connection upgrades
+// such as exec, attach and port-forward.
+func isLongRunning(r *http.Request) bool {
+	q := r.URL.Query()
+	if v := q.Get("watch"); v == "true" || v == "1" {
+		return true
+	}
+	if v := q.Get("follow"); v == "true" || v == "1" {
+		return true
+	}
+	if strings.Contains(r.URL.Path, "/watch/") {
+		return true
+	}
+	return r.Header.Get("Upgrade") != ""
+}
+
+func isConnectFailure(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,227 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+type countingServer struct {
+	*httptest.Server
+	hits   atomic.Int32
+	bodies []string
+}
+
+func newCountingServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) *countingServer {
+	t.Helper()
+	cs := &countingServer{}
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cs.hits.Add(1)
+		b, _ := io.ReadAll(r.Body)
+		cs.bodies = append(cs.bodies, string(b))
+		handler(w, r)
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func newRetryPool(t *testing.T, servers ...string) *BackendPool {
+	t.Helper()
+	br := &BackendRuntime{Name: "be"}
+	for _, s := range servers {
+		br.Targets = append(br.Targets, newServerTarget(t, br, s))
+	}
+	return &BackendPool{Name: "be", Targets: br.Targets, Backend: br}
+}
+
+func newTestRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Attempts:            2,
+		RetryOn:             RetryOnAll,
+		BudgetPercent:       DefaultRetryBudgetPercent,
+		MinRetryConcurrency: DefaultMinRetryConcurrency,
+		MaxRetryAfter:       time.Second,
+	}
+}
+
+func unavailable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+// ---------------------------------------------------------------------------
+// Tests — Retries
+// ---------------------------------------------------------------------------
+
+func TestForwarder_Retry_OtherTarget(t *testing.T) {
+	bad := newCountingServer(t, unavailable)
+	good := newCountingServer(t, ok)
+
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, bad.URL, good.URL), WithRetryPolicy(newTestRetryPolicy()))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if bad.hits.Load() != 1 || good.hits.Load() != 1 {
+		t.Errorf("expected one attempt per target, got bad=%d good=%d", bad.hits.Load(), good.hits.Load())
+	}
+}
+
+func TestForwarder_Retry_NotRetried(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+	}{
+		{name: "post", method: http.MethodPost, target: "/api/v1/namespaces/default/pods"},
+		{name: "watch", method: http.MethodGet, target: "/api/v1/pods?watch=true"},
+		{name: "follow", method: http.MethodGet, target: "/api/v1/namespaces/default/pods/p/log?follow=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := newCountingServer(t, unavailable)
+			h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, bad.URL), WithRetryPolicy(newTestRetryPolicy()))
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if rr.Code != http.StatusServiceUnavailable {
+				t.Fatalf("expected status 503, got %d", rr.Code)
+			}
+			if bad.hits.Load() != 1 {
+				t.Errorf("expected 1 attempt, got %d", bad.hits.Load())
+			}
+		})
+	}
+}
+
+func TestForwarder_Retry_ConnectFailure(t *testing.T) {
+	closed := httptest.NewServer(http.HandlerFunc(ok))
+	closed.Close()
+	good := newCountingServer(t, ok)
+
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, closed.URL, good.URL), WithRetryPolicy(newTestRetryPolicy()))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestForwarder_Retry_TooManyRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantHits   int32
+	}{
+		{name: "short retry-after", retryAfter: "0", wantHits: 2},
+		{name: "long retry-after", retryAfter: "10", wantHits: 1},
+		{name: "no retry-after", retryAfter: "", wantHits: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			})
+			h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL), WithRetryPolicy(newTestRetryPolicy()))
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+			if got := srv.hits.Load(); got != tt.wantHits {
+				t.Errorf("expected %d attempts, got %d", tt.wantHits, got)
+			}
+		})
+	}
+}
+
+func TestForwarder_Retry_ReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL), WithRetryPolicy(newTestRetryPolicy()))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/v1/namespaces/default/configmaps/cm", strings.NewReader(`{"data":{}}`)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if len(srv.bodies) != 2 || srv.bodies[0] != srv.bodies[1] || srv.bodies[1] == "" {
+		t.Errorf("expected body to be replayed, got %q", srv.bodies)
+	}
+}
+
+func TestForwarder_Retry_PerTryTimeout(t *testing.T) {
+	var calls atomic.Int32
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+	})
+	rp := newTestRetryPolicy()
+	rp.PerTryTimeout = 50 * time.Millisecond
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL), WithRetryPolicy(rp))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestForwarder_Retry_PerTryTimeout_NotRetryable(t *testing.T) {
+	srv := newCountingServer(t, slow(100*time.Millisecond))
+	rp := newTestRetryPolicy()
+	rp.PerTryTimeout = 10 * time.Millisecond
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL), WithRetryPolicy(rp))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/pods", strings.NewReader(`{}`)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected requests that are not retried to have no per try timeout, got %d", rr.Code)
+	}
+}
+
+func TestRetryPolicy_Budget(t *testing.T) {
+	rp := &RetryPolicy{BudgetPercent: 50, MinRetryConcurrency: 1}
+	rp.active.Store(4)
+
+	if !rp.acquire() || !rp.acquire() {
+		t.Fatal("expected two retries to fit in the budget")
+	}
+	if rp.acquire() {
+		t.Fatal("expected third retry to exceed the budget")
+	}
+	rp.release()
+	if !rp.acquire() {
+		t.Fatal("expected released retry to be available again")
+	}
+}
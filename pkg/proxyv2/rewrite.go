@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// RewriteRuntime is the compiled path rewrite of a route. Either Prefix is
+// replaced with Replacement, or the matches of Regex are replaced with
+// Substitution, which may refer to capture groups.
+type RewriteRuntime struct {
+	Prefix      string
+	Replacement string
+
+	Regex        *regexp.Regexp
+	Substitution string
+}
+
+// WithRewrite rewrites the path of requests before they are forwarded.
+func WithRewrite(rw *RewriteRuntime) HandlerOption {
+	return func(c *handlerConfig) {
+		c.rewrite = rw
+	}
+}
+
+// rewritePath returns the rewritten path p. Prefixes are only replaced at a
+// segment boundary. The result always starts with a slash.
+func (rw *RewriteRuntime) rewritePath(p string) string {
+	switch {
+	case rw.Regex != nil:
+		p = rw.Regex.ReplaceAllString(p, rw.Substitution)
+	case rw.Prefix != "":
+		if hasPathPrefix(p, rw.Prefix) {
+			rest := p[len(rw.Prefix):]
+			if strings.HasSuffix(rw.Replacement, "/") {
+				rest = strings.TrimPrefix(rest, "/")
+			}
+			p = rw.Replacement + rest
+		}
+	}
+
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
+
+// apply returns a copy of r with its path rewritten. r is returned as
+// is if rw is nil.
+func (rw *RewriteRuntime) apply(r *http.Request) *http.Request {
+	if rw == nil {
+		return r
+	}
+
+	p := rw.rewritePath(r.URL.Path)
+	if p == r.URL.Path {
+		return r
+	}
+
+	out := r.Clone(r.Context())
+	out.URL.Path = p
+	out.URL.RawPath = ""
+	return out
+}
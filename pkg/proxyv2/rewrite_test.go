@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Tests — Rewrites
+// ---------------------------------------------------------------------------
+
+func TestRewriteRuntime_RewritePath(t *testing.T) {
+	tests := []struct {
+		name string
+		rw   *RewriteRuntime
+		path string
+		want string
+	}{
+		{name: "strip prefix", rw: &RewriteRuntime{Prefix: "/clusters/prod"}, path: "/clusters/prod/api/v1/pods", want: "/api/v1/pods"},
+		{name: "strip prefix to root", rw: &RewriteRuntime{Prefix: "/clusters/prod"}, path: "/clusters/prod", want: "/"},
+		{name: "strip prefix no match", rw: &RewriteRuntime{Prefix: "/clusters/prod"}, path: "/api/v1/pods", want: "/api/v1/pods"},
+		{name: "strip prefix of longer segment", rw: &RewriteRuntime{Prefix: "/clusters/prod", Replacement: "/"}, path: "/clusters/production/api/v1/pods", want: "/clusters/production/api/v1/pods"},
+		{name: "replace prefix", rw: &RewriteRuntime{Prefix: "/prod", Replacement: "/k8s"}, path: "/prod/api", want: "/k8s/api"},
+		{name: "replace prefix with slash", rw: &RewriteRuntime{Prefix: "/prod/", Replacement: "/"}, path: "/prod/api", want: "/api"},
+		{
+			name: "regex with captures",
+			rw:   &RewriteRuntime{Regex: regexp.MustCompile(`^/clusters/([^/]+)(/.*)?$`), Substitution: "$2"},
+			path: "/clusters/dev/apis/apps/v1",
+			want: "/apis/apps/v1",
+		},
+		{
+			name: "regex with named captures",
+			rw:   &RewriteRuntime{Regex: regexp.MustCompile(`^/ns/(?P<ns>[^/]+)/pods$`), Substitution: "/api/v1/namespaces/${ns}/pods"},
+			path: "/ns/default/pods",
+			want: "/api/v1/namespaces/default/pods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rw.rewritePath(tt.path); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestForwarder_Rewrite(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+	})
+
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, srv.URL), WithRewrite(&RewriteRuntime{Prefix: "/clusters/prod"}))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/clusters/prod/api/v1/pods?limit=5", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if gotPath != "/api/v1/pods" || gotQuery != "limit=5" {
+		t.Errorf("expected /api/v1/pods?limit=5, got %s?%s", gotPath, gotQuery)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"crypto/tls"
 	"net/http"
 	"net/url"
-	"path"
-	"strings"
+	"regexp"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/amimof/multikube/pkg/cache"
 )
 
 type RuntimeConfig struct {
@@ -16,62 +19,187 @@ type RuntimeConfig struct {
 
 	Routes   CompiledRoutes
 	Backends map[string]*BackendRuntime
+
+	// Authorizer is nil if requests are not authorized by policies.
+	Authorizer *Authorizer
+	// Admission is nil if the objects of requests are not checked by
+	// policies.
+	Admission *Admission
 }
 
+// CompiledRoutes holds the routes of a RuntimeConfig. Ordered is sorted by
+// precedence and the first route whose conditions all match a request is
+// used. Default, the route without conditions, is used if none match.
 type CompiledRoutes struct {
-	Paths        []*RouteRuntime
-	PathPrefixes []*RouteRuntime
-	Headers      []*RouteRuntime
-	SNIExact     map[string][]*RouteRuntime
-	JWT          []*RouteRuntime
-	Default      *RouteRuntime
+	Ordered []*RouteRuntime
+	Default *RouteRuntime
 }
 
 type RouteRuntime struct {
-	Name    string
-	Kind    RouteMatchKind
-	Timeout time.Duration
-
-	Path       string
-	PathPrefix string
-	Header     *HeaderRuntime
-	SNI        string
-	JWT        *JWTRuntime
+	Name string
+	// Kind is the most specific condition of the route.
+	Kind     RouteMatchKind
+	Priority int32
+	Timeout  time.Duration
+
+	Path        string
+	PathPrefix  string
+	PathRegex   *regexp.Regexp
+	Header      *HeaderRuntime
+	Headers     []*HeaderRuntime
+	QueryParams []*QueryParamRuntime
+	SNI         string
+	JWT         *JWTRuntime
+
+	// Methods is nil if the route matches any method.
+	Methods map[string]struct{}
+
+	// Namespace, APIGroups, Resources and Verbs match the RequestInfo of a
+	// request. The sets are nil if the route matches any value.
+	Namespace *NamespaceRuntime
+	APIGroups map[string]struct{}
+	Resources map[string]struct{}
+	Verbs     map[string]struct{}
 
 	// Backend *BackendRuntime
 
 	Handler     http.Handler
 	BackendPool *BackendPool
+
+	// Retry is nil if requests to the route are not retried.
+	Retry *RetryPolicy
+
+	// IdleTimeout closes long running requests that have been idle for this
+	// long. MaxRequestBodyBytes limits request bodies if greater than zero.
+	IdleTimeout         time.Duration
+	MaxRequestBodyBytes int64
+
+	// Rewrite is nil if the path is forwarded unchanged.
+	Rewrite *RewriteRuntime
+
+	// RequestHeaders and ResponseHeaders are nil if headers are not changed.
+	RequestHeaders  *HeaderMutationRuntime
+	ResponseHeaders *HeaderMutationRuntime
+
+	// RateLimits must all allow a request for it to be forwarded.
+	RateLimits []*RateLimiter
+
+	// FanOut is nil if list requests are only sent to BackendPool.
+	FanOut *FanOutRuntime
 }
 
+// BackendPool distributes requests across a set of backend targets using
+// smooth weighted round robin. Unhealthy and ejected targets are skipped.
 type BackendPool struct {
 	Name    string
-	Targets []*BackendRuntime
-	rr      atomic.Uint32
+	Targets []*BackendTarget
+
+	// Backend is the backend the targets of the pool belong to.
+	Backend *BackendRuntime
+
+	mu      sync.Mutex
+	current []int
 }
 
-func (p *BackendPool) Next(r *http.Request) (*BackendRuntime, bool) {
-	targets := p.healthyTargets()
-	if len(targets) == 0 {
+// Next returns the next available target in the pool. The second return value
+// is false if the pool has no available targets.
+func (p *BackendPool) Next(r *http.Request) (*BackendTarget, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	if len(p.current) != len(p.Targets) {
+		p.current = make([]int, len(p.Targets))
+	}
+
+	total := 0
+	best := -1
+	for i, t := range p.Targets {
+		if !t.Available(now) {
+			continue
+		}
+		w := t.weight()
+		p.current[i] += w
+		total += w
+		if best == -1 || p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+
+	if best == -1 {
 		return nil, false
 	}
 
-	n := uint64(p.rr.Add(1))
-	idx := int(n % uint64(len(targets)))
-	return targets[idx], true
+	p.current[best] -= total
+	return p.Targets[best], true
+}
+
+// circuitBreaker returns the circuit breaker of the backend of the pool, or
+// nil if it has none.
+func (p *BackendPool) circuitBreaker() *CircuitBreaker {
+	if p.Backend == nil {
+		return nil
+	}
+	return p.Backend.CircuitBreaker
+}
+
+// flowControl returns the flow control of the backend of the pool, or nil if
+// it has none.
+func (p *BackendPool) flowControl() *FlowControl {
+	if p == nil || p.Backend == nil {
+		return nil
+	}
+	return p.Backend.FlowControl
 }
 
-func (p *BackendPool) healthyTargets() []*BackendRuntime {
-	out := make([]*BackendRuntime, 0, len(p.Targets))
-	out = append(out, p.Targets...)
-	return out
+func (p *BackendPool) responseCache() *cache.Cache {
+	if p.Backend == nil {
+		return nil
+	}
+	return p.Backend.Cache
 }
 
+// nextUntried returns the next available target that is not in tried. If all
+// available targets have been tried it returns the next available target.
+func (p *BackendPool) nextUntried(r *http.Request, tried []*BackendTarget) (*BackendTarget, bool) {
+	var first *BackendTarget
+	for range len(p.Targets) {
+		t, ok := p.Next(r)
+		if !ok {
+			return nil, false
+		}
+		if !slices.Contains(tried, t) {
+			return t, true
+		}
+		if first == nil {
+			first = t
+		}
+	}
+	return first, first != nil
+}
+
+// BackendTarget is a single API server endpoint of a backend.
 type BackendTarget struct {
 	ID      string
 	URL     *url.URL
 	Healthy atomic.Bool
 	Weight  int
+
+	// Backend is the backend this target belongs to.
+	Backend *BackendRuntime
+
+	// Passive outlier detection state.
+	consecutiveErrors atomic.Int64
+	ejections         atomic.Int64
+	ejectedUntil      atomic.Int64
+}
+
+func (t *BackendTarget) weight() int {
+	if t.Weight <= 0 {
+		return 1
+	}
+	return t.Weight
 }
 
 type RouteMatchKind uint8
@@ -82,12 +210,41 @@ const (
 	RouteMatchKindHeader
 	RouteMatchKindSNI
 	RouteMatchKindJWT
+	RouteMatchKindPathRegex
+	RouteMatchKindQueryParam
+	RouteMatchKindMethod
+	RouteMatchKindNamespace
+	RouteMatchKindAPIGroup
+	RouteMatchKindResource
+	RouteMatchKindVerb
 )
 
+// HeaderRuntime matches a header by exact Value, by Regex, or by its presence
+// or absence. An empty exact Value matches headers that are empty or missing.
 type HeaderRuntime struct {
 	Name      string
 	Canonical string
 	Value     string
+	Regex     *regexp.Regexp
+	Present   bool
+	Absent    bool
+}
+
+// NamespaceRuntime matches a namespace by Exact value, by Prefix or by Regex.
+type NamespaceRuntime struct {
+	Exact  string
+	Prefix string
+	Regex  *regexp.Regexp
+}
+
+// QueryParamRuntime matches a query parameter by exact Value, by Regex, or by
+// its presence or absence.
+type QueryParamRuntime struct {
+	Name    string
+	Value   string
+	Regex   *regexp.Regexp
+	Present bool
+	Absent  bool
 }
 
 type JWTRuntime struct {
@@ -97,106 +254,57 @@ type JWTRuntime struct {
 
 type BackendRuntime struct {
 	Name string
+	// Labels are the labels of the backend, available to policies.
+	Labels map[string]string
 
-	URL *url.URL
+	Targets []*BackendTarget
 
 	CacheTTL time.Duration
 
+	// Cache holds cached responses of the backend. It is nil if CacheTTL is
+	// zero.
+	Cache *cache.Cache
+
+	// HealthCheck is nil if the targets of the backend are not actively probed.
+	HealthCheck *HealthCheckRuntime
+
+	// OutlierDetection and CircuitBreaker are nil if disabled.
+	OutlierDetection *OutlierDetectionRuntime
+	CircuitBreaker   *CircuitBreaker
+
+	// FlowControl is nil if requests in flight to the backend are not
+	// limited.
+	FlowControl *FlowControl
+
 	TLSConfig *tls.Config
 	Transport http.RoundTripper
 
 	AuthInjector RequestAuthInjector
-}
 
-type RequestAuthInjector interface {
-	Apply(req *http.Request) error
-}
+	// Impersonation controls how the identity of clients is passed on.
+	Impersonation ImpersonationMode
 
-func (rc *RuntimeConfig) Match(r *http.Request) (*RouteRuntime, bool) {
-	if route, ok := rc.Routes.matchPath(r); ok {
-		return route, true
-	}
-	if route, ok := rc.Routes.matchPathPrefix(r); ok {
-		return route, true
-	}
-	if route, ok := rc.Routes.matchHeader(r); ok {
-		return route, true
-	}
-	if route, ok := rc.Routes.matchJWT(r); ok {
-		return route, true
-	}
-	if route, ok := rc.Routes.matchSNI(r); ok {
-		return route, true
-	}
-	if rc.Routes.Default != nil {
-		return rc.Routes.Default, true
-	}
-	return nil, false
-}
-
-func (cr *CompiledRoutes) matchPath(r *http.Request) (*RouteRuntime, bool) {
-	reqPath := r.URL.Path
-	for _, route := range cr.Paths {
-		match, err := path.Match(route.Path, reqPath)
-		if err != nil {
-			return nil, false
-		}
-		if match {
-			return route, true
-		}
-
-	}
-	return nil, false
-}
+	// RequestHeaders and ResponseHeaders are nil if headers are not changed.
+	RequestHeaders  *HeaderMutationRuntime
+	ResponseHeaders *HeaderMutationRuntime
 
-func (cr *CompiledRoutes) matchPathPrefix(r *http.Request) (*RouteRuntime, bool) {
-	path := r.URL.Path
-	for _, route := range cr.PathPrefixes {
-		if strings.HasPrefix(path, route.PathPrefix) {
-			return route, true
-		}
-	}
-	return nil, false
+	// Decoration is nil if the objects returned by the backend are not
+	// changed.
+	Decoration *DecorationRuntime
 }
 
-func (cr *CompiledRoutes) matchHeader(r *http.Request) (*RouteRuntime, bool) {
-	for _, route := range cr.Headers {
-		if route.Header == nil {
-			continue
-		}
-		if r.Header.Get(route.Header.Canonical) == route.Header.Value {
-			return route, true
-		}
-	}
-	return nil, false
+type RequestAuthInjector interface {
+	Apply(req *http.Request) error
 }
 
-func (cr *CompiledRoutes) matchJWT(r *http.Request) (*RouteRuntime, bool) {
-	claims, ok := JWTClaimsFromContext(r.Context())
-	if !ok {
-		return nil, false
-	}
-
-	for _, route := range cr.JWT {
-		if route.JWT == nil {
-			continue
-		}
-		if value, ok := claims[route.JWT.Claim]; ok && value == route.JWT.Value {
+func (rc *RuntimeConfig) Match(r *http.Request) (*RouteRuntime, bool) {
+	for _, route := range rc.Routes.Ordered {
+		if route.Matches(r) {
 			return route, true
 		}
 	}
-	return nil, false
-}
-
-func (cr *CompiledRoutes) matchSNI(r *http.Request) (*RouteRuntime, bool) {
-	sni, ok := SNIFromContext(r.Context())
-	if !ok || sni == "" {
-		return nil, false
-	}
-
-	routes := cr.SNIExact[sni]
-	for _, route := range routes {
-		return route, true
+	if rc.Routes.Default != nil {
+		return rc.Routes.Default, true
 	}
 	return nil, false
 }
@@ -204,8 +312,11 @@ func (cr *CompiledRoutes) matchSNI(r *http.Request) (*RouteRuntime, bool) {
 type contextKey string
 
 const (
-	ctxKeyJWTClaims contextKey = "jwt_claims"
-	ctxKeySNI       contextKey = "sni"
+	ctxKeyJWTClaims   contextKey = "jwt_claims"
+	ctxKeySNI         contextKey = "sni"
+	ctxKeySubject     contextKey = "subject"
+	ctxKeyIdentity    contextKey = "identity"
+	ctxKeyRequestInfo contextKey = "request_info"
 )
 
 func JWTClaimsFromContext(ctx context.Context) (map[string]string, bool) {
@@ -0,0 +1,333 @@
+package proxy
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func newTarget(host string, weight int, healthy bool) *BackendTarget {
+	t := &BackendTarget{
+		ID:     host,
+		URL:    &url.URL{Scheme: "https", Host: host},
+		Weight: weight,
+	}
+	t.Healthy.Store(healthy)
+	return t
+}
+
+func TestBackendPool_Next_Weighted(t *testing.T) {
+	pool := &BackendPool{
+		Name: "be",
+		Targets: []*BackendTarget{
+			newTarget("a", 5, true),
+			newTarget("b", 1, true),
+			newTarget("c", 1, true),
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	counts := map[string]int{}
+	seq := ""
+	for range 7 {
+		target, ok := pool.Next(req)
+		if !ok {
+			t.Fatal("expected a target")
+		}
+		counts[target.ID]++
+		seq += target.ID
+	}
+
+	if counts["a"] != 5 || counts["b"] != 1 || counts["c"] != 1 {
+		t.Errorf("unexpected distribution: %v", counts)
+	}
+	// Smooth weighted round robin interleaves the heavy target.
+	if seq != "aabacaa" {
+		t.Errorf("expected sequence %q, got %q", "aabacaa", seq)
+	}
+}
+
+func TestBackendPool_Next_SkipsUnhealthy(t *testing.T) {
+	pool := &BackendPool{
+		Name: "be",
+		Targets: []*BackendTarget{
+			newTarget("a", 1, false),
+			newTarget("b", 1, true),
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	for range 4 {
+		target, ok := pool.Next(req)
+		if !ok {
+			t.Fatal("expected a target")
+		}
+		if target.ID != "b" {
+			t.Fatalf("expected healthy target b, got %q", target.ID)
+		}
+	}
+
+	pool.Targets[1].Healthy.Store(false)
+	if _, ok := pool.Next(req); ok {
+		t.Fatal("expected no target when all targets are unhealthy")
+	}
+}
+
+func TestRuntimeConfig_Match_Compound(t *testing.T) {
+	compound := &RouteRuntime{
+		Name:       "compound",
+		SNI:        "prod.example.com",
+		PathPrefix: "/apis",
+		JWT:        &JWTRuntime{Claim: "team", Value: "platform"},
+	}
+	rc := &RuntimeConfig{
+		Routes: CompiledRoutes{
+			Ordered: []*RouteRuntime{compound},
+			Default: &RouteRuntime{Name: "default"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		sni    string
+		path   string
+		claims map[string]string
+		want   string
+	}{
+		{name: "all conditions", sni: "prod.example.com", path: "/apis/apps/v1", claims: map[string]string{"team": "platform"}, want: "compound"},
+		{name: "wrong sni", sni: "dev.example.com", path: "/apis/apps/v1", claims: map[string]string{"team": "platform"}, want: "default"},
+		{name: "wrong path", sni: "prod.example.com", path: "/api/v1", claims: map[string]string{"team": "platform"}, want: "default"},
+		{name: "missing claim", sni: "prod.example.com", path: "/apis/apps/v1", want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			ctx := context.WithValue(req.Context(), ctxKeySNI, tt.sni)
+			if tt.claims != nil {
+				ctx = context.WithValue(ctx, ctxKeyJWTClaims, tt.claims)
+			}
+
+			route, ok := rc.Match(req.WithContext(ctx))
+			if !ok {
+				t.Fatal("expected a route")
+			}
+			if route.Name != tt.want {
+				t.Errorf("expected route %q, got %q", tt.want, route.Name)
+			}
+		})
+	}
+}
+
+func TestRouteRuntime_Matches_Predicates(t *testing.T) {
+	rr := &RouteRuntime{
+		Name:      "r",
+		SNI:       "*.prod.example.com",
+		PathRegex: regexp.MustCompile(`^/api/v1/namespaces/[^/]+/pods`),
+		Methods:   map[string]struct{}{"GET": {}, "HEAD": {}},
+		Headers: []*HeaderRuntime{
+			{Name: "X-Tenant", Canonical: "X-Tenant", Regex: regexp.MustCompile(`^acme-`)},
+			{Name: "X-Debug", Canonical: "X-Debug", Absent: true},
+		},
+		QueryParams: []*QueryParamRuntime{{Name: "watch", Present: true}},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		sni    string
+		target string
+		header map[string]string
+		want   bool
+	}{
+		{name: "all conditions", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1", want: true},
+		{name: "sni case", sni: "API.Prod.Example.com", target: "/api/v1/namespaces/default/pods?watch=1", want: true},
+		{name: "sni too many labels", sni: "a.b.prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1"},
+		{name: "sni apex", sni: "prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1"},
+		{name: "method", method: "POST", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1"},
+		{name: "path", sni: "api.prod.example.com", target: "/api/v1/pods?watch=1"},
+		{name: "query param missing", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods"},
+		{name: "query param empty", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods?watch", want: true},
+		{name: "header regex", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1", header: map[string]string{"X-Tenant": "other"}},
+		{name: "header absent", sni: "api.prod.example.com", target: "/api/v1/namespaces/default/pods?watch=1", header: map[string]string{"X-Debug": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			req := httptest.NewRequest(method, tt.target, nil)
+			req.Header.Set("X-Tenant", "acme-prod")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), ctxKeySNI, tt.sni))
+
+			if got := rr.Matches(req); got != tt.want {
+				t.Errorf("expected Matches to return %v, got %v", tt.want, got)
+			}
+			if rr.Conditions() != 6 {
+				t.Errorf("expected 6 conditions, got %d", rr.Conditions())
+			}
+		})
+	}
+}
+
+func TestRouteRuntime_Matches_EmptyHeaderValue(t *testing.T) {
+	rr := &RouteRuntime{Headers: []*HeaderRuntime{{Name: "X-Tenant", Canonical: "X-Tenant"}}}
+
+	tests := []struct {
+		name   string
+		header []string
+		want   bool
+	}{
+		{name: "missing", want: true},
+		{name: "empty", header: []string{""}, want: true},
+		{name: "set", header: []string{"acme"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/pods", nil)
+			if tt.header != nil {
+				req.Header["X-Tenant"] = tt.header
+			}
+			if got := rr.Matches(req); got != tt.want {
+				t.Errorf("expected Matches to return %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRouteRuntime_Matches_PathPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   string
+		want   bool
+	}{
+		{prefix: "/clusters/prod", path: "/clusters/prod", want: true},
+		{prefix: "/clusters/prod", path: "/clusters/prod/api/v1/pods", want: true},
+		{prefix: "/clusters/prod", path: "/clusters/production/api/v1/pods"},
+		{prefix: "/clusters/", path: "/clusters/production/api/v1/pods", want: true},
+		{prefix: "/api", path: "/apis/apps/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix+" "+tt.path, func(t *testing.T) {
+			rr := &RouteRuntime{PathPrefix: tt.prefix}
+			if got := rr.Matches(httptest.NewRequest("GET", tt.path, nil)); got != tt.want {
+				t.Errorf("expected Matches to return %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRouteRuntime_Matches_TLSServerName(t *testing.T) {
+	rr := &RouteRuntime{Name: "r", SNI: "api.example.com"}
+
+	req := httptest.NewRequest("GET", "https://api.example.com/api", nil)
+	if !rr.Matches(req) {
+		t.Error("expected the server name of the TLS connection to be matched")
+	}
+}
+
+func TestRouteRuntime_Matches_RequestInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		rr     *RouteRuntime
+		method string
+		target string
+		want   bool
+	}{
+		{
+			name:   "namespace prefix",
+			rr:     &RouteRuntime{Namespace: &NamespaceRuntime{Prefix: "team-a-"}},
+			target: "/api/v1/namespaces/team-a-dev/pods",
+			want:   true,
+		},
+		{
+			name:   "namespace prefix mismatch",
+			rr:     &RouteRuntime{Namespace: &NamespaceRuntime{Prefix: "team-a-"}},
+			target: "/api/v1/namespaces/team-b-dev/pods",
+		},
+		{
+			name:   "namespace regex",
+			rr:     &RouteRuntime{Namespace: &NamespaceRuntime{Regex: regexp.MustCompile(`-(dev|test)$`)}},
+			target: "/apis/apps/v1/namespaces/team-a-test/deployments/web",
+			want:   true,
+		},
+		{
+			name:   "namespace of cluster scoped request",
+			rr:     &RouteRuntime{Namespace: &NamespaceRuntime{Regex: regexp.MustCompile(`.*`)}},
+			target: "/api/v1/nodes",
+		},
+		{
+			name:   "api group",
+			rr:     &RouteRuntime{APIGroups: map[string]struct{}{"ml.example.com": {}}},
+			target: "/apis/ml.example.com/v1/namespaces/default/trainingjobs",
+			want:   true,
+		},
+		{
+			name:   "core api group",
+			rr:     &RouteRuntime{APIGroups: map[string]struct{}{"": {}}},
+			target: "/api/v1/namespaces/default/pods",
+			want:   true,
+		},
+		{
+			name:   "subresource",
+			rr:     &RouteRuntime{Resources: map[string]struct{}{"pods/log": {}}},
+			target: "/api/v1/namespaces/default/pods/web/log",
+			want:   true,
+		},
+		{
+			name:   "resource does not match subresource",
+			rr:     &RouteRuntime{Resources: map[string]struct{}{"pods": {}}},
+			target: "/api/v1/namespaces/default/pods/web/log",
+		},
+		{
+			name:   "read verbs",
+			rr:     &RouteRuntime{Verbs: map[string]struct{}{"get": {}, "list": {}, "watch": {}}},
+			target: "/api/v1/namespaces/default/pods?watch=true",
+			want:   true,
+		},
+		{
+			name:   "write verb",
+			rr:     &RouteRuntime{Verbs: map[string]struct{}{"get": {}, "list": {}, "watch": {}}},
+			method: "POST",
+			target: "/api/v1/namespaces/default/pods",
+		},
+		{
+			name:   "non-resource request",
+			rr:     &RouteRuntime{Verbs: map[string]struct{}{"get": {}}},
+			target: "/version",
+		},
+		{
+			name: "rewritten path",
+			rr: &RouteRuntime{
+				Namespace: &NamespaceRuntime{Exact: "dev"},
+				Rewrite:   &RewriteRuntime{Prefix: "/clusters/prod", Replacement: "/"},
+			},
+			target: "/clusters/prod/api/v1/namespaces/dev/pods",
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			req := httptest.NewRequest(method, tt.target, nil)
+			req = req.WithContext(WithRequestInfo(req.Context(), NewRequestInfo(req)))
+
+			if got := tt.rr.Matches(req); got != tt.want {
+				t.Errorf("expected Matches to return %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
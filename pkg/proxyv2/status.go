@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Status is a minimal representation of a Kubernetes metav1.Status object.
+// It is what the proxy writes to clients whenever a request is rejected by the
+// proxy itself, so that kubectl and client-go can render a meaningful error.
+type Status struct {
+	Kind       string         `json:"kind"`
+	APIVersion string         `json:"apiVersion"`
+	Metadata   map[string]any `json:"metadata"`
+	Status     string         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Code       int            `json:"code"`
+}
+
+const (
+	StatusReasonUnauthorized       = "Unauthorized"
+	StatusReasonForbidden          = "Forbidden"
+	StatusReasonBadRequest         = "BadRequest"
+	StatusReasonServiceUnavailable = "ServiceUnavailable"
+	StatusReasonRequestTooLarge    = "RequestEntityTooLarge"
+	StatusReasonTooManyRequests    = "TooManyRequests"
+)
+
+// writeStatus writes a Kubernetes style Status response with the given code.
+func writeStatus(w http.ResponseWriter, code int, reason, message string) {
+	st := Status{
+		Kind:       "Status",
+		APIVersion: "v1",
+		Metadata:   map[string]any{},
+		Status:     "Failure",
+		Message:    message,
+		Reason:     reason,
+		Code:       code,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(st)
+}
@@ -0,0 +1,280 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultStreamIdleTimeout is how long an upgraded connection may be idle
+// before it is closed. It matches the default streaming connection idle
+// timeout of the kubelet.
+const DefaultStreamIdleTimeout = 4 * time.Hour
+
+// isUpgradeRequest returns true if r asks to switch protocols, as done by
+// exec, attach and port-forward over SPDY or WebSocket.
+func isUpgradeRequest(r *http.Request) bool {
+	if r.Header.Get("Upgrade") == "" {
+		return false
+	}
+	for _, v := range r.Header.Values("Connection") {
+		for token := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serveUpgrade proxies an upgrade request. The request is sent over a
+// dedicated connection to a target. If the target switches protocols the
+// client connection is hijacked and bytes are copied in both directions until
+// both sides are done or the session has been idle for too long.
+func (f *Forwarder) serveUpgrade(w http.ResponseWriter, r *http.Request, pool *BackendPool, cb *CircuitBreaker, cfg *handlerConfig) {
+	target, ok := pool.Next(r)
+	if !ok {
+		if cb != nil {
+			cb.Record(true, time.Now())
+		}
+		http.Error(w, "no healthy upstream", http.StatusBadGateway)
+		return
+	}
+
+	backendConn, resp, err := dialUpgrade(r, target, cfg.requestHeaders)
+	observeTarget(r, pool, target, resp, err)
+	if cb != nil {
+		recordCircuit(r, cb, resp, err)
+	}
+	if err != nil {
+		writeProxyError(w, err)
+		return
+	}
+
+	pool.mutateResponseHeaders(r, resp, cfg)
+
+	// The target refused to switch protocols, forward its response as is.
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer func() { _ = backendConn.Close() }()
+		copyHeader(w.Header(), resp.Header)
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+		return
+	}
+
+	clientConn, clientBuf, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		_ = backendConn.Close()
+		http.Error(w, "upgrade not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Clear any deadlines set by the server, the session manages its own.
+	_ = clientConn.SetDeadline(time.Time{})
+
+	if err := writeResponseHead(clientBuf.Writer, resp); err != nil {
+		_ = clientConn.Close()
+		_ = backendConn.Close()
+		return
+	}
+
+	protocol := strings.ToLower(resp.Header.Get("Upgrade"))
+	streamingSessions.WithLabelValues(pool.Name, protocol).Inc()
+	streamingSessionsTotal.WithLabelValues(pool.Name, protocol).Inc()
+	defer streamingSessions.WithLabelValues(pool.Name, protocol).Dec()
+
+	s := &streamSession{
+		backend:     pool.Name,
+		idleTimeout: cfg.streamIdleTimeout,
+	}
+	s.pipe(clientConn, clientBuf.Reader, backendConn.Conn, backendConn.reader)
+}
+
+// upgradeConn is a connection to a target along with the reader that the
+// upgrade response was read from, which may hold buffered bytes.
+type upgradeConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// dialUpgrade opens a dedicated connection to target and sends r over it.
+func dialUpgrade(r *http.Request, target *BackendTarget, routeHeaders *HeaderMutationRuntime) (*upgradeConn, *http.Response, error) {
+	conn, err := dialTarget(r.Context(), target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err := cloneRequestForTarget(r, target, routeHeaders)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	if err := out.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, out)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	return &upgradeConn{Conn: conn, reader: br}, resp, nil
+}
+
+// dialTarget dials target, using the TLS config of its backend for https
+// targets. HTTP/1.1 is enforced since upgrades are not possible over HTTP/2.
+func dialTarget(ctx context.Context, target *BackendTarget) (net.Conn, error) {
+	addr := target.URL.Host
+	if target.URL.Port() == "" {
+		port := "80"
+		if target.URL.Scheme == "https" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(target.URL.Hostname(), port)
+	}
+
+	d := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.URL.Scheme != "https" {
+		return conn, nil
+	}
+
+	cfg := &tls.Config{}
+	if target.Backend != nil && target.Backend.TLSConfig != nil {
+		cfg = target.Backend.TLSConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = target.URL.Hostname()
+	}
+	cfg.NextProtos = []string{"http/1.1"}
+
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// writeResponseHead writes the status line and headers of resp.
+func writeResponseHead(w *bufio.Writer, resp *http.Response) error {
+	if _, err := fmt.Fprintf(w, "HTTP/1.1 %s\r\n", resp.Status); err != nil {
+		return err
+	}
+	if err := resp.Header.Write(w); err != nil {
+		return err
+	}
+	if _, err := w.WriteString("\r\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// streamSession copies bytes between a client and a backend connection.
+type streamSession struct {
+	backend      string
+	idleTimeout  time.Duration
+	lastActivity atomic.Int64
+}
+
+// pipe copies in both directions until both are done. When one side closes
+// its write half, the write half of the other connection is closed as well so
+// that the remaining direction can drain. Both connections are closed when
+// pipe returns.
+func (s *streamSession) pipe(clientConn net.Conn, clientReader io.Reader, backendConn net.Conn, backendReader io.Reader) {
+	var once sync.Once
+	closeBoth := func() {
+		once.Do(func() {
+			_ = clientConn.Close()
+			_ = backendConn.Close()
+		})
+	}
+	defer closeBoth()
+
+	s.touch()
+
+	errc := make(chan error, 2)
+	go func() {
+		err := s.copy(backendConn, clientReader, "upstream")
+		if err == nil {
+			closeWrite(backendConn)
+		}
+		errc <- err
+	}()
+	go func() {
+		err := s.copy(clientConn, backendReader, "downstream")
+		if err == nil {
+			closeWrite(clientConn)
+		}
+		errc <- err
+	}()
+
+	var idle <-chan time.Time
+	if s.idleTimeout > 0 {
+		ticker := time.NewTicker(max(min(s.idleTimeout/4, time.Minute), 10*time.Millisecond))
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+
+	for done := 0; done < 2; {
+		select {
+		case err := <-errc:
+			done++
+			if err != nil {
+				closeBoth()
+			}
+		case now := <-idle:
+			if now.Sub(time.Unix(0, s.lastActivity.Load())) >= s.idleTimeout {
+				closeBoth()
+			}
+		}
+	}
+}
+
+// copy copies from src to dst until src returns EOF, recording activity.
+func (s *streamSession) copy(dst io.Writer, src io.Reader, direction string) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			s.touch()
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			streamingBytes.WithLabelValues(s.backend, direction).Add(float64(n))
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *streamSession) touch() {
+	s.lastActivity.Store(time.Now().UnixNano())
+}
+
+// closeWrite closes the write half of conn if supported.
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	}
+}
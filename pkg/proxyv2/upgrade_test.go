@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// newUpgradeBackend returns a server that switches to protocol and then hands
+// the raw connection to fn.
+func newUpgradeBackend(t *testing.T, protocol string, fn func(conn net.Conn, rw *bufio.ReadWriter)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isUpgradeRequest(r) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: " + protocol + "\r\n\r\n")
+		_ = rw.Flush()
+		fn(conn, rw)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dialUpgradeProxy connects to the proxy at addr, sends an upgrade request and
+// returns the connection once the proxy has switched protocols.
+func dialUpgradeProxy(t *testing.T, addr, protocol string) (*net.TCPConn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	req := "POST /api/v1/namespaces/default/pods/p/exec?command=sh HTTP/1.1\r\nHost: proxy\r\nConnection: Upgrade\r\nUpgrade: " + protocol + "\r\n\r\n"
+	if _, err := conn.Write([]byte(req)); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected status 101, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Upgrade"); got != protocol {
+		t.Fatalf("expected upgrade %q, got %q", protocol, got)
+	}
+	return conn.(*net.TCPConn), br
+}
+
+func newUpgradeProxy(t *testing.T, backend string, opts ...HandlerOption) *httptest.Server {
+	t.Helper()
+	h := NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, backend), opts...)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// ---------------------------------------------------------------------------
+// Tests — Upgrades
+// ---------------------------------------------------------------------------
+
+func TestForwarder_Upgrade_HalfClose(t *testing.T) {
+	for _, protocol := range []string{"SPDY/3.1", "websocket"} {
+		t.Run(protocol, func(t *testing.T) {
+			backend := newUpgradeBackend(t, protocol, func(conn net.Conn, rw *bufio.ReadWriter) {
+				// Read until the client closes its write half, then answer.
+				in, _ := io.ReadAll(rw)
+				_, _ = rw.WriteString("pong:" + string(in))
+				_ = rw.Flush()
+			})
+			proxy := newUpgradeProxy(t, backend.URL)
+
+			conn, br := dialUpgradeProxy(t, proxy.Listener.Addr().String(), protocol)
+			if _, err := conn.Write([]byte("ping")); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if err := conn.CloseWrite(); err != nil {
+				t.Fatalf("close write: %v", err)
+			}
+
+			_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			out, err := io.ReadAll(br)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if string(out) != "pong:ping" {
+				t.Errorf("expected %q, got %q", "pong:ping", out)
+			}
+		})
+	}
+}
+
+func TestForwarder_Upgrade_IdleTimeout(t *testing.T) {
+	backend := newUpgradeBackend(t, "SPDY/3.1", func(conn net.Conn, rw *bufio.ReadWriter) {
+		_, _ = io.Copy(io.Discard, rw)
+	})
+	proxy := newUpgradeProxy(t, backend.URL, WithStreamIdleTimeout(50*time.Millisecond))
+
+	conn, br := dialUpgradeProxy(t, proxy.Listener.Addr().String(), "SPDY/3.1")
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := br.ReadByte(); err != io.EOF {
+		t.Fatalf("expected idle session to be closed with EOF, got %v", err)
+	}
+}
+
+func TestForwarder_Upgrade_Refused(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer backend.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/pods/p/exec", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "SPDY/3.1")
+
+	rr := httptest.NewRecorder()
+	NewForwarder(http.DefaultTransport).Handler(newRetryPool(t, backend.URL)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "forbidden") {
+		t.Errorf("expected backend body to be forwarded, got %q", rr.Body.String())
+	}
+}
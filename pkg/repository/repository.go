@@ -17,7 +17,9 @@ import (
 	backendv1 "github.com/amimof/multikube/api/backend/v1"
 	cav1 "github.com/amimof/multikube/api/ca/v1"
 	certv1 "github.com/amimof/multikube/api/certificate/v1"
+	credentialv1 "github.com/amimof/multikube/api/credential/v1"
 	metav1 "github.com/amimof/multikube/api/meta/v1"
+	policyv1 "github.com/amimof/multikube/api/policy/v1"
 	routev1 "github.com/amimof/multikube/api/route/v1"
 )
 
@@ -68,6 +70,22 @@ func NewCertificateRepo[T *certv1.Certificate](db DB) *Repo[*certv1.Certificate]
 	return NewRepo(db, CertificateCodec, []byte("certificate/"), []byte("i/certificate/"), []byte("i/idx/certificate"))
 }
 
+var CredentialCodec = ProtoCodec[*credentialv1.Credential]{
+	New: func() *credentialv1.Credential { return &credentialv1.Credential{} },
+}
+
+func NewCredentialRepo[T *credentialv1.Credential](db DB) *Repo[*credentialv1.Credential] {
+	return NewRepo(db, CredentialCodec, []byte("credential/"), []byte("i/credential/"), []byte("i/idx/credential"))
+}
+
+var PolicyCodec = ProtoCodec[*policyv1.Policy]{
+	New: func() *policyv1.Policy { return &policyv1.Policy{} },
+}
+
+func NewPolicyRepo[T *policyv1.Policy](db DB) *Repo[*policyv1.Policy] {
+	return NewRepo(db, PolicyCodec, []byte("policy/"), []byte("i/policy/"), []byte("i/idx/policy"))
+}
+
 var RouteCodec = ProtoCodec[*routev1.Route]{
 	New: func() *routev1.Route { return &routev1.Route{} },
 }